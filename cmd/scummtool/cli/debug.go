@@ -0,0 +1,366 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/apoloval/scumm-go"
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/apoloval/scumm-go/vm4"
+	"github.com/spf13/cobra"
+)
+
+var debugCmd = &cobra.Command{
+	Use:   "debug [index file]",
+	Short: "Debug a SCUMM application from its index file",
+	Args:  cobra.ExactArgs(1),
+	RunE:  func(cmd *cobra.Command, args []string) error { return doDebug(args[0]) },
+}
+
+var debugFlags struct {
+	Lenient bool
+}
+
+func doDebug(path string) error {
+	eng, err := scumm.NewEngine(path, vm.NewHeadless(vm.NewRealClock()), nil)
+	if err != nil {
+		return err
+	}
+	if debugFlags.Lenient {
+		eng.SetLenient(log.New(os.Stderr, "", 0))
+	}
+	d := &debugger{
+		Debugger: vm.NewDebugger(eng),
+		eng:      eng,
+		out:      os.Stdout,
+		symbols:  make(map[*vm.Script]*vm.SymbolTable),
+	}
+	d.stopped(d.Start(eng.Run))
+	return d.repl(os.Stdin)
+}
+
+// debugger is the interactive debugger of the debug command.
+type debugger struct {
+	*vm.Debugger
+	eng *vm.Engine
+	out io.Writer
+
+	// symbols are the symbol tables of the scripts, with the labels of their jumps.
+	symbols map[*vm.Script]*vm.SymbolTable
+}
+
+// debugCommand is a command of the interactive debugger.
+type debugCommand struct {
+	name  string
+	alias string
+	args  string
+	help  string
+	run   func(d *debugger, args []string) error
+}
+
+// debugCommands is initialized in init, since its help command refers to it.
+var debugCommands []debugCommand
+
+func init() {
+	debugCommands = []debugCommand{
+		{"break", "b", "SCRIPT ADDR|LABEL", "set a breakpoint", (*debugger).doBreak},
+		{"delete", "d", "SCRIPT ADDR|LABEL", "remove a breakpoint", (*debugger).doDelete},
+		{"breakpoints", "bl", "", "list the breakpoints", (*debugger).doBreakpoints},
+		{"step", "s", "[COUNT]", "execute the next instructions", (*debugger).doStep},
+		{"continue", "c", "", "run until the next breakpoint", (*debugger).doContinue},
+		{"print", "p", "VAR", "print a variable, like VAR_EGO, VAR_10, BIT_5 or LOCAL_2",
+			(*debugger).doPrint},
+		{"set", "", "VAR VALUE", "set a variable", (*debugger).doSet},
+		{"slots", "", "", "list the running threads", (*debugger).doSlots},
+		{"list", "l", "[COUNT]", "disassemble around the current instruction", (*debugger).doList},
+		{"help", "h", "", "show this help", (*debugger).doHelp},
+		{"quit", "q", "", "exit the debugger", nil},
+	}
+}
+
+func (d *debugger) repl(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(d.out, "(scumm) ")
+		if !scanner.Scan() {
+			fmt.Fprintln(d.out)
+			return scanner.Err()
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		found := false
+		for _, cmd := range debugCommands {
+			if fields[0] != cmd.name && fields[0] != cmd.alias {
+				continue
+			}
+			found = true
+			if cmd.run == nil {
+				return nil
+			}
+			if err := cmd.run(d, fields[1:]); err != nil {
+				fmt.Fprintf(d.out, "Error: %v\n", err)
+			}
+		}
+		if !found {
+			fmt.Fprintf(d.out, "Unknown command %q, try help\n", fields[0])
+		}
+	}
+}
+
+func (d *debugger) doHelp(args []string) error {
+	for _, cmd := range debugCommands {
+		name := cmd.name
+		if cmd.alias != "" {
+			name += ", " + cmd.alias
+		}
+		fmt.Fprintf(d.out, "  %-16s %-18s %s\n", name, cmd.args, cmd.help)
+	}
+	return nil
+}
+
+func (d *debugger) doBreak(args []string) error {
+	script, addr, err := d.parseLocation(args)
+	if err != nil {
+		return err
+	}
+	d.SetBreakpoint(script, addr)
+	fmt.Fprintf(d.out, "Breakpoint set at script %d, %04X\n", script, addr)
+	return nil
+}
+
+func (d *debugger) doDelete(args []string) error {
+	script, addr, err := d.parseLocation(args)
+	if err != nil {
+		return err
+	}
+	if !d.ClearBreakpoint(script, addr) {
+		return fmt.Errorf("no breakpoint at script %d, %04X", script, addr)
+	}
+	return nil
+}
+
+func (d *debugger) doBreakpoints(args []string) error {
+	for _, bp := range d.Breakpoints() {
+		fmt.Fprintf(d.out, "  script %d, %04X\n", bp.Script, bp.Address)
+	}
+	return nil
+}
+
+func (d *debugger) doStep(args []string) error {
+	count := 1
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid count %q", args[0])
+		}
+		count = n
+	}
+	for i := 0; i < count; i++ {
+		th, err := d.Step()
+		if errors.Is(err, vm.ErrEngineDone) {
+			return err
+		}
+		if th == nil || i == count-1 {
+			d.stopped(th, err)
+			break
+		}
+	}
+	return nil
+}
+
+func (d *debugger) doContinue(args []string) error {
+	th, err := d.Continue()
+	if errors.Is(err, vm.ErrEngineDone) {
+		return err
+	}
+	d.stopped(th, err)
+	return nil
+}
+
+func (d *debugger) doPrint(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: print VAR")
+	}
+	ref, err := d.parseVar(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(d.out, "%s = %d\n", args[0], ref.Read(vm.ExecContextFrom(d.eng, d.Current())))
+	return nil
+}
+
+func (d *debugger) doSet(args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: set VAR VALUE")
+	}
+	ref, err := d.parseVar(args[0])
+	if err != nil {
+		return err
+	}
+	value, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid value %q", args[1])
+	}
+	ref.Write(vm.ExecContextFrom(d.eng, d.Current()), value)
+	return nil
+}
+
+func (d *debugger) doSlots(args []string) error {
+	for i, th := range d.eng.Slots() {
+		if th == nil {
+			continue
+		}
+		marker := " "
+		if th == d.Current() {
+			marker = "*"
+		}
+		fmt.Fprintf(d.out, "%s %2d: script %d", marker, i, th.ScriptID())
+		if obj := th.ObjectID(); obj != 0 {
+			fmt.Fprintf(d.out, " (object %d)", obj)
+		}
+		fmt.Fprintf(d.out, " at %04X, %s\n", th.Address(), threadStatusNames[th.Status()])
+	}
+	return nil
+}
+
+var threadStatusNames = map[vm.ThreadStatus]string{
+	vm.ThreadDead:    "dead",
+	vm.ThreadRunning: "running",
+	vm.ThreadPaused:  "paused",
+}
+
+func (d *debugger) doList(args []string) error {
+	count := 5
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid count %q", args[0])
+		}
+		count = n
+	}
+	th := d.Current()
+	if th == nil {
+		return vm.ErrEngineDone
+	}
+	script := th.Script()
+	st := d.symbolsOf(script)
+	bps := make(map[vm.Breakpoint]bool)
+	for _, bp := range d.Breakpoints() {
+		bps[bp] = true
+	}
+	for i := max(th.IP()-count, 0); i < min(th.IP()+count+1, len(script.Code)); i++ {
+		addr := script.Frames[i].StartAddress
+		marker := "  "
+		if i == th.IP() {
+			marker = "=>"
+		}
+		if bps[vm.Breakpoint{Script: script.ID, Address: addr}] {
+			marker = marker[:1] + "*"
+		}
+		label, ok := st.LookupSymbol(vm.SymbolTypeLabel, addr, false)
+		if ok {
+			label += ":"
+		}
+		fmt.Fprintf(d.out, "%s %04X: %-12s%s\n", marker, addr, label,
+			vm.DisplayInstruction(st, script.Code[i]))
+	}
+	return nil
+}
+
+// stopped reports where the engine stopped, or the end of its execution.
+func (d *debugger) stopped(th *vm.Thread, err error) {
+	if th == nil {
+		if err != nil {
+			fmt.Fprintf(d.out, "Engine failed: %v\n", reportError(err))
+		} else {
+			fmt.Fprintln(d.out, "Engine finished")
+		}
+		return
+	}
+	script := th.Script()
+	fmt.Fprintf(d.out, "Stopped at script %d, %04X", script.ID, th.Address())
+	if th.IP() < len(script.Code) {
+		fmt.Fprintf(d.out, ": %s", vm.DisplayInstruction(d.symbolsOf(script), script.Code[th.IP()]))
+	}
+	fmt.Fprintln(d.out)
+}
+
+// symbolsOf returns the symbol table of the script, with the default variables of the engine and
+// the labels of the jumps of the script.
+func (d *debugger) symbolsOf(script *vm.Script) *vm.SymbolTable {
+	if st, ok := d.symbols[script]; ok {
+		return st
+	}
+	st := vm4.DefaultSymbolTable()
+	for _, inst := range script.Code {
+		vm.DisplayInstruction(st, inst)
+	}
+	d.symbols[script] = st
+	return st
+}
+
+// parseLocation parses a script ID and an address of the script, given as an hexadecimal number or
+// as a label.
+func (d *debugger) parseLocation(args []string) (vm.ScriptID, uint16, error) {
+	if len(args) != 2 {
+		return 0, 0, errors.New("expected a script and an address or label")
+	}
+	id, err := vm.ParseScriptID(args[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	if addr, err := strconv.ParseUint(strings.TrimPrefix(args[1], "0x"), 16, 16); err == nil {
+		return id, uint16(addr), nil
+	}
+	script, err := d.eng.LookupScript(id)
+	if err != nil {
+		return 0, 0, err
+	}
+	addr, ok := d.symbolsOf(script).LookupValue(vm.SymbolTypeLabel, args[1])
+	if !ok {
+		return 0, 0, fmt.Errorf("unknown label %s in script %d", args[1], id)
+	}
+	return id, addr, nil
+}
+
+// parseVar parses the name of a variable, either a well-known word variable or a word, bit or
+// local variable followed by its number.
+func (d *debugger) parseVar(name string) (vm.VarRef, error) {
+	if idx, ok := vm4.DefaultSymbolTable().LookupValue(vm.SymbolTypeVar, name); ok {
+		return vm.VarRef{VarID: idx}, nil
+	}
+	for _, kind := range []struct {
+		prefix string
+		max    int
+		flag   uint16
+	}{
+		{"VAR_", vm.MaxWords, 0},
+		{"BIT_", vm.MaxBits, 0x8000},
+		{"LOCAL_", vm.MaxLocals, 0x4000},
+	} {
+		if s, ok := strings.CutPrefix(name, kind.prefix); ok {
+			idx, err := strconv.Atoi(s)
+			if err != nil || idx < 0 || idx >= kind.max {
+				return vm.VarRef{}, fmt.Errorf("invalid variable %s", name)
+			}
+			if kind.flag == 0x4000 && d.Current() == nil {
+				return vm.VarRef{}, fmt.Errorf("no current thread for %s", name)
+			}
+			return vm.VarRef{VarID: uint16(idx) | kind.flag}, nil
+		}
+	}
+	return vm.VarRef{}, fmt.Errorf("unknown variable %s", name)
+}
+
+func init() {
+	debugCmd.Flags().BoolVar(&debugFlags.Lenient,
+		"lenient", false, "log and skip the instructions that are not implemented instead of failing")
+}
@@ -23,4 +23,6 @@ func init() {
 	rootCmd.AddCommand(inspectCmd)
 	rootCmd.AddCommand(charsetCmd)
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(debugCmd)
+	rootCmd.AddCommand(soundCmd)
 }
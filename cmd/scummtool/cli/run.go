@@ -1,7 +1,17 @@
 package cli
 
 import (
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"log"
+	"os"
+	"path"
+
 	"github.com/apoloval/scumm-go"
+	"github.com/apoloval/scumm-go/vm"
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +22,144 @@ var runCmd = &cobra.Command{
 	RunE:  func(cmd *cobra.Command, args []string) error { return doRun(args[0]) },
 }
 
+var runFlags struct {
+	Headless bool
+	Frames   string
+	Ticks    int
+	Input    string
+	SaveDir  string
+	Trace    string
+	TraceFmt string
+	Lenient  bool
+}
+
 func doRun(path string) error {
-	return scumm.Run(path)
+	var store vm.Store
+	if runFlags.SaveDir != "" {
+		store = vm.NewDirStore(runFlags.SaveDir)
+	}
+	fe, err := runFrontend()
+	if err != nil {
+		return err
+	}
+	eng, err := scumm.NewEngine(path, fe, store)
+	if err != nil {
+		return err
+	}
+	if runFlags.Trace != "" {
+		output, err := os.Create(runFlags.Trace)
+		if err != nil {
+			return err
+		}
+		defer output.Close()
+
+		tracer, err := newTracer(runFlags.TraceFmt, output)
+		if err != nil {
+			return err
+		}
+		eng.SetTracer(tracer)
+	}
+	if runFlags.Lenient {
+		eng.SetLenient(log.New(os.Stderr, "", 0))
+	}
+	return reportError(eng.RunTicks(runFlags.Ticks))
+}
+
+// reportError returns the execution errors with the backtrace of the active slots in the message.
+func reportError(err error) error {
+	var ee *vm.ExecError
+	if errors.As(err, &ee) {
+		return errors.New(ee.Report())
+	}
+	return err
+}
+
+func runFrontend() (vm.Frontend, error) {
+	if !runFlags.Headless {
+		if runFlags.Frames != "" || runFlags.Ticks != 0 || runFlags.Input != "" {
+			return nil, errors.New("the frames, ticks and input flags require headless mode")
+		}
+		return vm.NewHeadless(vm.NewRealClock()), nil
+	}
+
+	var fe vm.Frontend = &framesFrontend{
+		Headless: vm.NewHeadless(new(vm.FakeClock)),
+		dir:      runFlags.Frames,
+	}
+	if runFlags.Frames != "" {
+		if err := os.MkdirAll(runFlags.Frames, 0755); err != nil {
+			return nil, err
+		}
+	}
+	if runFlags.Input != "" {
+		events, err := readInputScript(runFlags.Input)
+		if err != nil {
+			return nil, err
+		}
+		fe = vm.NewScripted(fe, events)
+	}
+	return fe, nil
+}
+
+func newTracer(format string, w io.Writer) (vm.Tracer, error) {
+	switch format {
+	case "text":
+		return vm.NewTextTracer(w), nil
+	case "json":
+		return vm.NewJSONTracer(w), nil
+	default:
+		return nil, fmt.Errorf("unknown trace format %q", format)
+	}
+}
+
+func readInputScript(path string) ([]vm.ScriptedInput, error) {
+	input, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer input.Close()
+
+	return vm.ReadInputScript(input)
+}
+
+// framesFrontend is a headless frontend that writes every frame presented as a numbered PNG file
+// into a directory, unless the directory is empty.
+type framesFrontend struct {
+	*vm.Headless
+	dir string
+}
+
+func (fe *framesFrontend) Present(frame *image.Paletted) error {
+	if err := fe.Headless.Present(frame); err != nil {
+		return err
+	}
+	if fe.dir == "" {
+		return nil
+	}
+	output, err := os.Create(path.Join(fe.dir, fmt.Sprintf("%06d.png", fe.Frames())))
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	return png.Encode(output, frame)
+}
+
+func init() {
+	runCmd.Flags().BoolVar(&runFlags.Headless,
+		"headless", false, "run without display, as fast as possible")
+	runCmd.Flags().StringVar(&runFlags.Frames,
+		"frames", "", "directory where the frames are written as PNG files in headless mode")
+	runCmd.Flags().IntVar(&runFlags.Ticks,
+		"ticks", 0, "number of frames to run in headless mode, or 0 to run until the game ends")
+	runCmd.Flags().StringVar(&runFlags.SaveDir,
+		"save-dir", "", "directory where the saved games and variables are written instead of memory")
+	runCmd.Flags().StringVar(&runFlags.Input,
+		"input", "", "file with the input events to feed at given frames in headless mode")
+	runCmd.Flags().StringVar(&runFlags.Trace,
+		"trace", "", "file where the execution trace is written")
+	runCmd.Flags().StringVar(&runFlags.TraceFmt,
+		"trace-format", "text", "format of the execution trace, text or json")
+	runCmd.Flags().BoolVar(&runFlags.Lenient,
+		"lenient", false, "log and skip the instructions that are not implemented instead of failing")
 }
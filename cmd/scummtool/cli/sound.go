@@ -0,0 +1,15 @@
+package cli
+
+import (
+	"github.com/apoloval/scumm-go/cmd/scummtool/cli/sound"
+	"github.com/spf13/cobra"
+)
+
+var soundCmd = &cobra.Command{
+	Use:   "sound",
+	Short: "Manipulate SCUMM sound resources",
+}
+
+func init() {
+	soundCmd.AddCommand(sound.ExportCmd)
+}
@@ -0,0 +1,68 @@
+package sound
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/apoloval/scumm-go"
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/apoloval/scumm-go/vm4"
+	"github.com/spf13/cobra"
+)
+
+var ExportCmd = &cobra.Command{
+	Use:   "export [index file] [sound ID]",
+	Short: "Export the PC speaker version of a SCUMM sound into a WAV file",
+	Args:  cobra.ExactArgs(2),
+	RunE:  func(cmd *cobra.Command, args []string) error { return export(args[0], args[1]) },
+}
+
+var exportFlags struct {
+	Output     string
+	SampleRate int
+}
+
+func export(indexPath, ref string) error {
+	id, err := strconv.Atoi(ref)
+	if err != nil {
+		return fmt.Errorf("invalid sound ID %q", ref)
+	}
+	if exportFlags.SampleRate <= 0 {
+		return fmt.Errorf("invalid sample rate %d", exportFlags.SampleRate)
+	}
+	rm, err := scumm.FromIndexFile(indexPath)
+	if err != nil {
+		return err
+	}
+	sound, err := rm.GetSound(vm.SoundID(id))
+	if err != nil {
+		return err
+	}
+	if sound.Speaker == nil {
+		return fmt.Errorf("sound %d has no PC speaker data", id)
+	}
+	tones, err := vm4.DecodeSpeakerTones(sound.Speaker)
+	if err != nil {
+		return err
+	}
+
+	output, err := os.Create(exportFlags.Output)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	samples := vm.RenderSpeaker(tones, exportFlags.SampleRate)
+	if err := vm.WriteWAV(output, exportFlags.SampleRate, samples); err != nil {
+		return err
+	}
+	return output.Close()
+}
+
+func init() {
+	ExportCmd.Flags().StringVarP(&exportFlags.Output,
+		"output", "o", "sound.wav", "output file")
+	ExportCmd.Flags().IntVarP(&exportFlags.SampleRate,
+		"rate", "r", 22050, "sample rate of the output in Hz")
+}
@@ -1,23 +1,6 @@
 package scumm
 
-import "image/color"
+import "github.com/apoloval/scumm-go/vm"
 
 // ColorPaletteEGA is the color palette of the standard EGA graphics card.
-var ColorPaletteEGA color.Palette = color.Palette{
-	color.RGBA{0x00, 0x00, 0x00, 0xff},
-	color.RGBA{0x00, 0x00, 0xaa, 0xff},
-	color.RGBA{0x00, 0xaa, 0x00, 0xff},
-	color.RGBA{0x00, 0xaa, 0xaa, 0xff},
-	color.RGBA{0xaa, 0x00, 0x00, 0xff},
-	color.RGBA{0xaa, 0x00, 0xaa, 0xff},
-	color.RGBA{0xaa, 0x55, 0x00, 0xff},
-	color.RGBA{0xaa, 0xaa, 0xaa, 0xff},
-	color.RGBA{0x55, 0x55, 0x55, 0xff},
-	color.RGBA{0x55, 0x55, 0xff, 0xff},
-	color.RGBA{0x55, 0xff, 0x55, 0xff},
-	color.RGBA{0x55, 0xff, 0xff, 0xff},
-	color.RGBA{0xff, 0x55, 0x55, 0xff},
-	color.RGBA{0xff, 0x55, 0xff, 0xff},
-	color.RGBA{0xff, 0xff, 0x55, 0xff},
-	color.RGBA{0xff, 0xff, 0xff, 0xff},
-}
+var ColorPaletteEGA = vm.ColorPaletteEGA
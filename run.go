@@ -2,11 +2,35 @@ package scumm
 
 import "github.com/apoloval/scumm-go/vm"
 
-func Run(indexPath string) error {
-	rm, err := FromIndexFile(indexPath)
+// Run runs a SCUMM application from its index file. The files written by the application, like
+// the saved games, are kept in the given store, or in memory if it is nil.
+func Run(indexPath string, store vm.Store) error {
+	return RunHeadless(indexPath, vm.NewHeadless(vm.NewRealClock()), store, 0)
+}
+
+// RunHeadless runs a SCUMM application from its index file on the given frontend for the given
+// number of frames, or until the application ends if ticks is not positive. The files written by
+// the application are kept in the given store, or in memory if it is nil.
+func RunHeadless(indexPath string, fe vm.Frontend, store vm.Store, ticks int) error {
+	eng, err := NewEngine(indexPath, fe, store)
 	if err != nil {
 		return err
 	}
+	return eng.RunTicks(ticks)
+}
 
-	return vm.NewEngine(rm).Run()
+// NewEngine creates an engine for the SCUMM application of the given index file, running on the
+// given frontend. The files written by the application are kept in the given store, or in memory if
+// it is nil.
+func NewEngine(indexPath string, fe vm.Frontend, store vm.Store) (*vm.Engine, error) {
+	rm, err := FromIndexFile(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	eng := vm.NewEngine(rm, fe)
+	if store != nil {
+		eng.SetStore(store)
+	}
+	return eng, nil
 }
@@ -0,0 +1,259 @@
+package vm
+
+// ActorID is the ID of an actor.
+type ActorID int
+
+// MaxActors is the number of actors in the actor table. Actor 0 is never used.
+const MaxActors = 13
+
+// ActorPaletteSize is the number of colors of the actor palette.
+const ActorPaletteSize = 32
+
+// Actor is the runtime state of an actor.
+type Actor struct {
+	// ID is the ID of the actor.
+	ID ActorID
+
+	// Name is the name of the actor.
+	Name string
+
+	// Costume is the costume the actor is dressed with, or 0 if it has none.
+	Costume CostumeID
+
+	// Room is the room where the actor is placed, or 0 if it is not in any room.
+	Room RoomID
+
+	// X and Y are the position of the actor in the room.
+	X int
+	Y int
+
+	// Elevation is the number of pixels the actor is drawn above its position.
+	Elevation int
+
+	// Facing is the direction the actor is facing, in degrees clockwise starting from north.
+	Facing int
+
+	// Width is the width of the actor in pixels.
+	Width int
+
+	// ScaleX and ScaleY are the scale factors of the actor, being 0xFF the natural size.
+	ScaleX int
+	ScaleY int
+
+	// SpeedX and SpeedY are the number of pixels the actor advances per step when walking.
+	SpeedX int
+	SpeedY int
+
+	// TalkColor is the color of the text spoken by the actor.
+	TalkColor int
+
+	// Sound is the sound played when the actor animates.
+	Sound SoundID
+
+	// InitFrame, WalkFrame, StandFrame, TalkStartFrame and TalkStopFrame are the animations of
+	// the costume played when the actor is initialized, walks, stands still and starts or stops
+	// talking.
+	InitFrame      int
+	WalkFrame      int
+	StandFrame     int
+	TalkStartFrame int
+	TalkStopFrame  int
+
+	// Frame is the last animation started for the actor.
+	Frame int
+
+	// Palette maps the colors of the costume into the colors used to draw the actor. The entries set
+	// to 0xFF use the colors of the costume palette.
+	Palette [ActorPaletteSize]int
+
+	// IgnoreBoxes indicates the actor is not constrained to the walk boxes of the room.
+	IgnoreBoxes bool
+
+	// Moving indicates whether the actor is walking.
+	Moving int
+
+	// WalkBox is the walk box where the actor stands.
+	WalkBox int
+
+	walk actorWalk
+	cost actorCostume
+}
+
+// Init resets the actor attributes to their defaults. If full is set, the costume, room and
+// position are reset as well. This is what the actor default sub-op of the actor instruction does
+// when full is not set.
+func (a *Actor) Init(full bool) {
+	if full {
+		a.Costume = 0
+		a.Room = 0
+		a.X = 0
+		a.Y = 0
+		a.Facing = 180
+		a.Name = ""
+	}
+	a.Elevation = 0
+	a.Width = 24
+	a.TalkColor = 15
+	a.ScaleX = 0xFF
+	a.ScaleY = 0xFF
+	a.Sound = 0
+	a.SpeedX = 8
+	a.SpeedY = 2
+	a.IgnoreBoxes = false
+	a.Moving = 0
+	a.WalkBox = 0
+	a.Frame = 0
+	a.InitAnimations()
+	for i := range a.Palette {
+		a.Palette[i] = 0xFF
+	}
+	a.cost = actorCostume{}
+}
+
+// InitAnimations resets the animations of the actor to the default ones.
+func (a *Actor) InitAnimations() {
+	a.InitFrame = 1
+	a.WalkFrame = 2
+	a.StandFrame = 3
+	a.TalkStartFrame = 4
+	a.TalkStopFrame = 5
+}
+
+// Put places the actor at the given position of its room.
+func (a *Actor) Put(x, y int) {
+	a.X = x
+	a.Y = y
+	a.Moving = 0
+}
+
+// FacingCode returns the facing direction of the actor as the codes used by the v4 scripts.
+func (a *Actor) FacingCode() int {
+	return newDirToOldDir(a.Facing)
+}
+
+// Animate runs the given animation command on the actor. The commands in the upper range stop the
+// actor or turn it to the direction encoded in the two lower bits. Any other value starts the
+// corresponding costume animation in the next frame.
+func (a *Actor) Animate(anim int) {
+	dir := oldDirToNewDir(anim % 4)
+	switch anim / 4 {
+	case 0x3F:
+		a.Moving = 0
+	case 0x3E, 0x3D:
+		a.Facing = dir
+	default:
+		a.Frame = anim
+		a.cost.pending = append(a.cost.pending, anim)
+	}
+}
+
+// Actor returns the actor with the given ID, or nil if the ID is out of range.
+func (e *Engine) Actor(id ActorID) *Actor {
+	if id < 1 || id >= MaxActors {
+		return nil
+	}
+	return &e.actors[id]
+}
+
+// ActorAt returns the topmost actor of the current room that is at the given position, or nil if
+// there is none. As long as actors are not drawn, the actors are considered as high as the room.
+func (e *Engine) ActorAt(x, y int) *Actor {
+	for i := MaxActors - 1; i > 0; i-- {
+		a := &e.actors[i]
+		if a.Room == 0 || a.Room != e.roomID {
+			continue
+		}
+		if x >= a.X-a.Width/2 && x <= a.X+a.Width/2 && y <= a.Y-a.Elevation {
+			return a
+		}
+	}
+	return nil
+}
+
+// ObjectPosition returns the position of the given actor or object. For objects, the position is
+// the one where actors walk to reach them. If the object is not in the current room, ok is false.
+func (e *Engine) ObjectPosition(id int) (x, y int, ok bool) {
+	if id < MaxActors {
+		if a := e.Actor(ActorID(id)); a != nil {
+			return a.X, a.Y, true
+		}
+		return 0, 0, false
+	}
+	if e.room == nil {
+		return 0, 0, false
+	}
+	if obj := e.room.Object(ObjectID(id)); obj != nil {
+		return obj.WalkX, obj.WalkY, true
+	}
+	return 0, 0, false
+}
+
+// ClosestActor returns the ID of the actor of the current room that is closest to the given actor
+// or object, or 0xFF if there is none.
+func (e *Engine) ClosestActor(id int) int {
+	x, y, ok := e.ObjectPosition(id)
+	if !ok {
+		return 0xFF
+	}
+	closest, dist := 0xFF, 0xFF
+	for i := MaxActors - 1; i > 0; i-- {
+		a := &e.actors[i]
+		if i == id || a.Room == 0 || a.Room != e.roomID {
+			continue
+		}
+		if d := max(abs(a.X-x), abs(a.Y-y)); d < dist {
+			closest, dist = i, d
+		}
+	}
+	return closest
+}
+
+// FaceActorTo turns the given actor to the left or to the right, looking at the given actor or
+// object.
+func (e *Engine) FaceActorTo(a *Actor, id int) {
+	x, _, ok := e.ObjectPosition(id)
+	if !ok {
+		return
+	}
+	if x > a.X {
+		a.Facing = 90
+	} else {
+		a.Facing = 270
+	}
+}
+
+// PutActorAtObject places the given actor at the walking position of the given object. If the
+// object is not in the current room, the actor is placed at a fixed position.
+func (e *Engine) PutActorAtObject(a *Actor, id int) {
+	x, y, ok := e.ObjectPosition(id)
+	if !ok {
+		x, y = 240, 120
+	}
+	e.PutActor(a, x, y)
+}
+
+// oldDirToNewDir converts the v4 direction codes (west, east, south, north) into degrees.
+func oldDirToNewDir(dir int) int {
+	return [4]int{270, 90, 180, 0}[dir&3]
+}
+
+// newDirToOldDir converts degrees into the v4 direction codes (west, east, south, north).
+func newDirToOldDir(dir int) int {
+	switch {
+	case dir >= 71 && dir <= 109:
+		return 1
+	case dir >= 109 && dir <= 251:
+		return 2
+	case dir >= 251 && dir <= 289:
+		return 0
+	default:
+		return 3
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,55 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActorOps(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x13, 0x03, 0x01, 0x05, 0x0E, 0x0C, 0x0F, 'G', 'u', 'y', 0x00, 0xFF, // 0000: Actor 3 CO=5 TKCOL=12 NAME="Guy"
+			0x2D, 0x03, 0x0A, // 000C: PutActorInRoom 3 10
+			0x01, 0x03, 0xA0, 0x00, 0x64, 0x00, // 000F: ActorPut 3 160 100
+			0x11, 0x03, 0xF9, // 0015: AnimateActor 3 F9
+			0x43, 0x64, 0x00, 0x03, 0x00, // 0018: VAR_100 = GetActorX 3
+			0x71, 0x65, 0x00, 0x03, // 001D: VAR_101 = GetActorCostume 3
+			0x63, 0x66, 0x00, 0x03, // 0021: VAR_102 = GetActorFacing 3
+			0x03, 0x67, 0x00, 0x03, // 0025: VAR_103 = GetActorRoom 3
+			0xA0, // 0029: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	a := eng.Actor(3)
+	assert.Equal(t, "Guy", a.Name)
+	assert.Equal(t, 12, a.TalkColor)
+	assert.Equal(t, 100, a.Y)
+	assert.Equal(t, 90, a.Facing)
+	assert.Equal(t, 160, eng.ReadWord(100))
+	assert.Equal(t, 5, eng.ReadWord(101))
+	assert.Equal(t, 1, eng.ReadWord(102))
+	assert.Equal(t, 10, eng.ReadWord(103))
+}
+
+func TestActorOpsOrder(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x13, 0x03, 0x0E, 0x0C, 0x0A, 0x04, 0x06, 0x03, 0xFF, // 0000: Actor 3 TKCOL=12 DEF STEPDIST=6,3
+			0xA0, // 0009: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	a := eng.Actor(3)
+	assert.Equal(t, 15, a.TalkColor)
+	assert.Equal(t, 6, a.SpeedX)
+	assert.Equal(t, 3, a.SpeedY)
+}
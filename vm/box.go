@@ -0,0 +1,246 @@
+package vm
+
+// Point is a position in a room.
+type Point struct {
+	X int
+	Y int
+}
+
+// BoxFlags are the flags of a walk box.
+type BoxFlags uint8
+
+const (
+	BoxXFlip       BoxFlags = 0x08
+	BoxYFlip       BoxFlags = 0x10
+	BoxIgnoreScale BoxFlags = 0x20
+	BoxLocked      BoxFlags = 0x40
+	BoxInvisible   BoxFlags = 0x80
+)
+
+// Box is a walk box of a room. Walk boxes are quadrilaterals that delimit the areas of the room
+// where actors can walk.
+type Box struct {
+	UL    Point
+	UR    Point
+	LR    Point
+	LL    Point
+	Mask  uint8
+	Flags BoxFlags
+	Scale int
+}
+
+// Walkable returns true if actors are allowed to walk through the box.
+func (b *Box) Walkable() bool {
+	return b.Flags&(BoxLocked|BoxInvisible) == 0
+}
+
+// Contains returns true if the point is inside the box or on its edges.
+func (b *Box) Contains(p Point) bool {
+	corners := b.corners()
+	var pos, neg bool
+	for i, c := range corners {
+		n := corners[(i+1)%4]
+		cross := (n.X-c.X)*(p.Y-c.Y) - (n.Y-c.Y)*(p.X-c.X)
+		if cross > 0 {
+			pos = true
+		} else if cross < 0 {
+			neg = true
+		}
+	}
+	if pos && neg {
+		return false
+	}
+
+	// Degenerated boxes (lines and points) are only checked against their bounding rectangle.
+	minX, minY, maxX, maxY := corners[0].X, corners[0].Y, corners[0].X, corners[0].Y
+	for _, c := range corners[1:] {
+		minX, minY = min(minX, c.X), min(minY, c.Y)
+		maxX, maxY = max(maxX, c.X), max(maxY, c.Y)
+	}
+	return p.X >= minX && p.X <= maxX && p.Y >= minY && p.Y <= maxY
+}
+
+// ClosestPoint returns the point of the box that is closest to p.
+func (b *Box) ClosestPoint(p Point) Point {
+	if b.Contains(p) {
+		return p
+	}
+	corners := b.corners()
+	best, dist := corners[0], -1
+	for i, c := range corners {
+		q := closestPointOnSegment(c, corners[(i+1)%4], p)
+		if d := sqDist(p, q); dist < 0 || d < dist {
+			best, dist = q, d
+		}
+	}
+	return best
+}
+
+func (b *Box) corners() [4]Point {
+	return [4]Point{b.UL, b.UR, b.LR, b.LL}
+}
+
+// neighborOf returns true if the box shares a segment of a vertical or horizontal edge with other.
+func (b *Box) neighborOf(other *Box) bool {
+	c1, c2 := b.corners(), other.corners()
+	for i := range c1 {
+		a1, a2 := c1[i], c1[(i+1)%4]
+		for j := range c2 {
+			b1, b2 := c2[j], c2[(j+1)%4]
+			switch {
+			case a1.X == a2.X && b1.X == b2.X && a1.X == b1.X:
+				if overlap(a1.Y, a2.Y, b1.Y, b2.Y) {
+					return true
+				}
+			case a1.Y == a2.Y && b1.Y == b2.Y && a1.Y == b1.Y:
+				if overlap(a1.X, a2.X, b1.X, b2.X) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// BoxMatrix tells which box must be crossed next to go from one box to another.
+type BoxMatrix [][]int
+
+// NewBoxMatrix computes the box matrix of the given walk boxes. Boxes that are not walkable are
+// left out of the routes.
+func NewBoxMatrix(boxes []Box) BoxMatrix {
+	n := len(boxes)
+	m := make(BoxMatrix, n)
+	dist := make([][]int, n)
+	for i := range boxes {
+		m[i] = make([]int, n)
+		dist[i] = make([]int, n)
+		for j := range boxes {
+			m[i][j], dist[i][j] = -1, n+1
+			switch {
+			case i == j:
+				m[i][j], dist[i][j] = j, 0
+			case boxes[i].Walkable() && boxes[j].Walkable() && boxes[i].neighborOf(&boxes[j]):
+				m[i][j], dist[i][j] = j, 1
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if d := dist[i][k] + dist[k][j]; d < dist[i][j] {
+					dist[i][j] = d
+					m[i][j] = m[i][k]
+				}
+			}
+		}
+	}
+	return m
+}
+
+// NextBox returns the box that must be crossed next to go from box from to box to, or -1 if to is
+// not reachable from from.
+func (m BoxMatrix) NextBox(from, to int) int {
+	if from < 0 || from >= len(m) || to < 0 || to >= len(m) {
+		return -1
+	}
+	return m[from][to]
+}
+
+// BoxAt returns the index of the walkable box of the current room that contains the point, or -1
+// if there is none.
+func (e *Engine) BoxAt(p Point) int {
+	if e.room == nil {
+		return -1
+	}
+	for i := len(e.room.Boxes) - 1; i >= 0; i-- {
+		if b := &e.room.Boxes[i]; b.Walkable() && b.Contains(p) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ActorInBox returns true if the actor stands inside the given box of the current room.
+func (e *Engine) ActorInBox(a *Actor, box int) bool {
+	b := e.box(box)
+	return b != nil && b.Contains(Point{a.X, a.Y})
+}
+
+// SetBoxFlags sets the flags of the given box of the current room.
+func (e *Engine) SetBoxFlags(box int, flags BoxFlags) {
+	if b := e.box(box); b != nil {
+		b.Flags = flags
+	}
+}
+
+// SetBoxScale sets the scale of the given box of the current room.
+func (e *Engine) SetBoxScale(box int, scale int) {
+	if b := e.box(box); b != nil {
+		b.Scale = scale
+	}
+}
+
+// CreateBoxMatrix computes again the box matrix of the current room, so the changes in the box
+// flags are taken into account when routing actors.
+func (e *Engine) CreateBoxMatrix() {
+	if e.room == nil {
+		e.boxMatrix = nil
+		return
+	}
+	e.boxMatrix = NewBoxMatrix(e.room.Boxes)
+}
+
+func (e *Engine) box(i int) *Box {
+	if e.room == nil || i < 0 || i >= len(e.room.Boxes) {
+		return nil
+	}
+	return &e.room.Boxes[i]
+}
+
+// adjustToBoxes returns the point of the walkable boxes of the current room that is closest to p,
+// and the box it belongs to. If there are no walkable boxes, p is returned with box -1.
+func (e *Engine) adjustToBoxes(p Point) (Point, int) {
+	if e.room == nil {
+		return p, -1
+	}
+	best, box, dist := p, -1, -1
+	for i := len(e.room.Boxes) - 1; i >= 0; i-- {
+		b := &e.room.Boxes[i]
+		if !b.Walkable() {
+			continue
+		}
+		q := b.ClosestPoint(p)
+		if d := sqDist(p, q); dist < 0 || d < dist {
+			best, box, dist = q, i, d
+		}
+		if dist == 0 {
+			break
+		}
+	}
+	return best, box
+}
+
+func closestPointOnSegment(a, b, p Point) Point {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	l := dx*dx + dy*dy
+	if l == 0 {
+		return a
+	}
+	t := (p.X-a.X)*dx + (p.Y-a.Y)*dy
+	switch {
+	case t <= 0:
+		return a
+	case t >= l:
+		return b
+	}
+	return Point{X: a.X + dx*t/l, Y: a.Y + dy*t/l}
+}
+
+func sqDist(a, b Point) int {
+	dx, dy := a.X-b.X, a.Y-b.Y
+	return dx*dx + dy*dy
+}
+
+func overlap(a1, a2, b1, b2 int) bool {
+	return max(min(a1, a2), min(b1, b2)) < min(max(a1, a2), max(b1, b2))
+}
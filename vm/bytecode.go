@@ -170,26 +170,26 @@ func (d *BytecodeDecoder) DecodeRelativeJump() Constant {
 	return pos.Add(rel)
 }
 
-// DecodeString decodes a null-terminated string from the bytecode.
+// DecodeString decodes a null-terminated string from the bytecode. The string keeps the raw bytes,
+// including the escape sequences, so they can be interpreted when the string is printed.
 func (d *BytecodeDecoder) DecodeString() string {
-	var s string
+	var s []byte
 	for {
 		b := d.DecodeByte()
-		if b == 0 {
-			return s
+		if b == 0 || d.err != nil {
+			return string(s)
 		}
+		s = append(s, b)
 		if b == 0xFF {
 			// Escape sequence
 			b = d.DecodeByte()
-			s += string(b)
+			s = append(s, b)
 			switch b {
 			case 1, 2, 3, 8:
 			default:
-				s += string(d.DecodeByte())
-				s += string(d.DecodeByte())
+				s = append(s, d.DecodeByte(), d.DecodeByte())
 			}
 		}
-		s += string(b)
 	}
 }
 
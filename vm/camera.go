@@ -0,0 +1,218 @@
+package vm
+
+// CameraMode is the way the camera moves over the room.
+type CameraMode int
+
+const (
+	// CameraNormal is the mode of a camera that stays where it was placed.
+	CameraNormal CameraMode = iota + 1
+
+	// CameraFollowActor is the mode of a camera that scrolls to keep an actor on screen.
+	CameraFollowActor
+
+	// CameraPanning is the mode of a camera that scrolls to a position.
+	CameraPanning
+)
+
+const (
+	// CameraSpeed is the number of pixels the camera scrolls per frame, unless VarCameraFastX is set.
+	CameraSpeed = 8
+
+	// cameraLeftTrigger and cameraRightTrigger are the screen columns, in 8 pixel strips, beyond which
+	// an actor followed by the camera makes it scroll.
+	cameraLeftTrigger  = 10
+	cameraRightTrigger = 30
+)
+
+// Camera is the view of the room shown on screen.
+type Camera struct {
+	// X is the horizontal center of the view in room coordinates, and DestX is the position the
+	// camera is scrolling to.
+	X     int
+	DestX int
+
+	Mode CameraMode
+
+	// Follows is the actor followed by the camera, or 0 if there is none.
+	Follows ActorID
+
+	movingToActor bool
+}
+
+// Camera returns the current state of the camera.
+func (e *Engine) Camera() Camera {
+	return e.camera
+}
+
+// ScreenLeft returns the room coordinate shown at the left edge of the screen.
+func (e *Engine) ScreenLeft() int {
+	return (e.camera.X/8 - ScreenWidth/16) * 8
+}
+
+// SetCameraAt places the camera at the given position right away, stopping any panning.
+func (e *Engine) SetCameraAt(x int) error {
+	e.camera.Mode = CameraNormal
+	e.camera.X = x
+	e.camera.movingToActor = false
+	return e.setCameraAt(x)
+}
+
+// PanCameraTo makes the camera scroll to the given position.
+func (e *Engine) PanCameraTo(x int) {
+	e.camera.DestX = x
+	e.camera.Mode = CameraPanning
+	e.camera.movingToActor = false
+}
+
+// ActorFollowCamera makes the camera follow the given actor, switching to its room if needed. The
+// inventory script is run afterwards, since the inventory shown depends on the followed actor.
+func (e *Engine) ActorFollowCamera(a *Actor) error {
+	e.camera.Mode = CameraFollowActor
+	e.camera.Follows = a.ID
+	e.camera.movingToActor = false
+	if a.Room != e.roomID {
+		if err := e.LoadRoom(a.Room); err != nil {
+			return err
+		}
+		e.camera.Mode = CameraFollowActor
+		e.camera.X = a.X
+		if err := e.setCameraAt(a.X); err != nil {
+			return err
+		}
+	}
+	if t := a.X/8 - e.ScreenLeft()/8; t < cameraLeftTrigger || t > cameraRightTrigger {
+		if err := e.setCameraAt(a.X); err != nil {
+			return err
+		}
+	}
+	return e.runInventoryScript(0)
+}
+
+// SetScrollLimits sets the range of positions of the camera, within the limits of the room.
+func (e *Engine) SetScrollLimits(minX, maxX int) {
+	lo, hi := ScreenWidth/2, e.roomWidth()-ScreenWidth/2
+	e.words[VarCameraMinX] = max(min(minX, hi), lo)
+	e.words[VarCameraMaxX] = max(min(maxX, hi), lo)
+}
+
+// IsCameraMoving returns true if the camera has not reached its destination yet.
+func (e *Engine) IsCameraMoving() bool {
+	return e.camera.X != e.camera.DestX
+}
+
+// resetCamera places the camera at the left edge of a new room, allowing it to scroll over the
+// whole room.
+func (e *Engine) resetCamera() {
+	e.words[VarCameraMinX] = ScreenWidth / 2
+	e.words[VarCameraMaxX] = e.roomWidth() - ScreenWidth/2
+	e.camera.Mode = CameraNormal
+	e.camera.X = ScreenWidth / 2
+	e.camera.DestX = ScreenWidth / 2
+	e.camera.movingToActor = false
+	e.words[VarCameraPosX] = e.camera.X
+}
+
+// setCameraAt moves the camera to the given position, within the scroll limits, and runs the
+// scroll script. A camera following an actor is only moved right away if the position is far.
+func (e *Engine) setCameraAt(x int) error {
+	if e.camera.Mode != CameraFollowActor || abs(x-e.camera.X) > ScreenWidth/2 {
+		e.camera.X = x
+	}
+	e.camera.DestX = x
+	e.camera.X = min(max(e.camera.X, e.words[VarCameraMinX]), e.words[VarCameraMaxX])
+	e.cameraMoved()
+	return e.runScrollScript()
+}
+
+// moveCamera scrolls the camera one step towards its destination. A camera following an actor
+// starts scrolling when the actor gets close to the edges of the screen.
+func (e *Engine) moveCamera() error {
+	pos := e.camera.X
+	snap := e.words[VarCameraFastX] != 0
+	c := &e.camera
+	c.X &^= 7
+
+	switch {
+	case c.X < e.words[VarCameraMinX]:
+		if snap {
+			c.X = e.words[VarCameraMinX]
+		} else {
+			c.X += CameraSpeed
+		}
+		e.cameraMoved()
+		return nil
+	case c.X > e.words[VarCameraMaxX]:
+		if snap {
+			c.X = e.words[VarCameraMaxX]
+		} else {
+			c.X -= CameraSpeed
+		}
+		e.cameraMoved()
+		return nil
+	}
+
+	var a *Actor
+	if c.Mode == CameraFollowActor {
+		if a = e.Actor(c.Follows); a != nil {
+			if t := a.X/8 - e.ScreenLeft()/8; t < cameraLeftTrigger || t > cameraRightTrigger {
+				switch {
+				case !snap:
+					c.movingToActor = true
+				case t > 35:
+					c.DestX = a.X + 80
+				case t < 5:
+					c.DestX = a.X - 80
+				}
+			}
+		}
+	}
+	if c.movingToActor {
+		if a = e.Actor(c.Follows); a != nil {
+			c.DestX = a.X
+		}
+	}
+	c.DestX = min(max(c.DestX, e.words[VarCameraMinX]), e.words[VarCameraMaxX])
+
+	if snap {
+		c.X = c.DestX
+	} else if c.X < c.DestX {
+		c.X += CameraSpeed
+	} else if c.X > c.DestX {
+		c.X -= CameraSpeed
+	}
+	if c.movingToActor && a != nil && c.X/8 == a.X/8 {
+		c.movingToActor = false
+	}
+	e.cameraMoved()
+
+	if c.X != pos {
+		return e.runScrollScript()
+	}
+	return nil
+}
+
+// cameraMoved keeps the camera within the room and updates VarCameraPosX.
+func (e *Engine) cameraMoved() {
+	if e.camera.X < ScreenWidth/2 {
+		e.camera.X = ScreenWidth / 2
+	} else if e.camera.X > e.roomWidth()-ScreenWidth/2 {
+		e.camera.X = e.roomWidth() - ScreenWidth/2
+	}
+	e.words[VarCameraPosX] = e.camera.X
+}
+
+func (e *Engine) runScrollScript() error {
+	if script := e.words[VarScrollScript]; script != 0 {
+		e.words[VarCameraPosX] = e.camera.X
+		return e.StartScript(ScriptID(script), nil, false)
+	}
+	return nil
+}
+
+// roomWidth returns the width of the current room, or the width of the screen if there is none.
+func (e *Engine) roomWidth() int {
+	if e.room == nil || e.room.Width == 0 {
+		return ScreenWidth
+	}
+	return int(e.room.Width)
+}
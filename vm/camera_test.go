@@ -0,0 +1,78 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCameraPan(t *testing.T) {
+	rm := gameResources{
+		scriptResources: scriptResources{
+			1: {
+				0x1A, 0x1B, 0x00, 0x02, 0x00, // 0000: VAR_SCROLL_SCRIPT = 2
+				0x72, 0x0A, // 0005: LoadRoom 10
+				0x32, 0x2C, 0x01, // 0007: SetCameraAt 300
+				0x12, 0xE0, 0x01, // 000A: PanCameraTo 480
+				0xAE, 0x03, // 000D: WaitForCamera
+				0x1A, 0x64, 0x00, 0x01, 0x00, // 000F: VAR_100 = 1
+				0xA0, // 0014: StopObjectCode
+			},
+			2: {
+				0x9A, 0x65, 0x00, 0x02, 0x00, // 0000: VAR_101 = VAR_CAMERA_POS_X
+				0xA0, // 0005: StopObjectCode
+			},
+		},
+		rooms: map[vm.RoomID]vm.Room{
+			10: {Width: 640},
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+	assert.Equal(t, 300, eng.ReadWord(101))
+	assert.Equal(t, 160, eng.ReadWord(vm.VarCameraMinX))
+	assert.Equal(t, 480, eng.ReadWord(vm.VarCameraMaxX))
+	assert.True(t, eng.IsCameraMoving())
+
+	for i := 0; i < 23; i++ {
+		require.NoError(t, eng.RunFrame())
+	}
+	assert.Equal(t, 480, eng.ReadWord(vm.VarCameraPosX))
+	assert.Equal(t, 480, eng.ReadWord(101))
+	assert.Equal(t, 320, eng.ScreenLeft())
+	assert.Equal(t, 0, eng.ReadWord(100))
+
+	require.NoError(t, eng.RunFrame())
+	assert.Equal(t, 1, eng.ReadWord(100))
+
+	eng.SetScrollLimits(100, 1000)
+	assert.Equal(t, 160, eng.ReadWord(vm.VarCameraMinX))
+	assert.Equal(t, 480, eng.ReadWord(vm.VarCameraMaxX))
+}
+
+func TestCameraFollowActor(t *testing.T) {
+	rm := gameResources{
+		scriptResources: scriptResources{},
+		rooms: map[vm.RoomID]vm.Room{
+			10: {Width: 640},
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	a := eng.Actor(1)
+	a.Room = 10
+	a.X, a.Y = 100, 100
+	require.NoError(t, eng.ActorFollowCamera(a))
+	assert.Equal(t, vm.RoomID(10), eng.Room().ID)
+	assert.Equal(t, 160, eng.Camera().X)
+
+	a.X = 400
+	for i := 0; i < 40 && eng.Camera().X/8 != a.X/8; i++ {
+		require.NoError(t, eng.RunFrame())
+	}
+	assert.Equal(t, 400, eng.Camera().X)
+	assert.Equal(t, vm.CameraFollowActor, eng.Camera().Mode)
+}
@@ -9,6 +9,9 @@ import (
 	"github.com/apoloval/scumm-go/ioutils"
 )
 
+// CharsetID is the ID of a charset resource.
+type CharsetID int
+
 // Charset is a charset resource of a SCUMM game.
 type Charset struct {
 	ColorMap     CharsetColorMap
@@ -26,6 +29,28 @@ func (c Charset) CharWidth(ch rune) int {
 	return int(char.Width) + int(char.XOffset)
 }
 
+// StringWidth returns the width of the widest line of the text. Escape sequences are not drawn,
+// but the ones that break the line start a new line.
+func (c Charset) StringWidth(text string) int {
+	var width, line int
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		if ch != 0xFF || i+1 == len(text) {
+			line += c.CharWidth(rune(ch))
+			continue
+		}
+		i++
+		switch text[i] {
+		case 1, 8:
+			width, line = max(width, line), 0
+		case 2, 3:
+		default:
+			i += 2
+		}
+	}
+	return max(width, line)
+}
+
 // PrintChar prints a character of a charset into an image.
 func (c Charset) PrintChar(ch rune, img *image.Paletted, loc image.Point) int {
 	char := c.Characters[ch]
@@ -75,3 +100,35 @@ type Character struct {
 	YOffset int8
 	Glyph   []byte
 }
+
+// InitCharset makes the charset with the given ID the current one, loading it if needed.
+func (e *Engine) InitCharset(id CharsetID) error {
+	if e.charsets[id] == nil {
+		cs, err := e.rm.GetCharset(id)
+		if err != nil {
+			return fmt.Errorf("could not load charset %d: %w", id, err)
+		}
+		e.charsets[id] = cs
+	}
+	e.charset = id
+	return nil
+}
+
+// Charset returns the current charset, or nil if it is not available.
+func (e *Engine) Charset() *Charset {
+	return e.loadCharset(e.charset)
+}
+
+// loadCharset returns the charset with the given ID, or nil if it is not available.
+func (e *Engine) loadCharset(id CharsetID) *Charset {
+	if cs, ok := e.charsets[id]; ok {
+		return cs
+	}
+	// Missing charsets are remembered, so they are not looked up again on every call.
+	cs, err := e.rm.GetCharset(id)
+	if err != nil {
+		cs = nil
+	}
+	e.charsets[id] = cs
+	return cs
+}
@@ -0,0 +1,55 @@
+package vm
+
+import "time"
+
+// Jiffy is the time unit of the engine timers, a sixtieth of a second.
+const Jiffy = time.Second / 60
+
+// Clock is the source of time that paces the execution of the engine.
+type Clock interface {
+	// Now returns the time elapsed since the clock was created.
+	Now() time.Duration
+
+	// Sleep blocks until the given duration has elapsed.
+	Sleep(d time.Duration)
+}
+
+// NewRealClock returns a clock that follows the wall time.
+func NewRealClock() Clock {
+	return &realClock{start: time.Now()}
+}
+
+type realClock struct {
+	start time.Time
+}
+
+func (c *realClock) Now() time.Duration {
+	return time.Since(c.start)
+}
+
+func (c *realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// FakeClock is a clock that only advances when the engine sleeps on it or when told to. It allows
+// to run the engine faster than real time with the same results.
+type FakeClock struct {
+	now time.Duration
+}
+
+// Now implements the Clock interface.
+func (c *FakeClock) Now() time.Duration {
+	return c.now
+}
+
+// Sleep implements the Clock interface. It advances the clock by d without blocking.
+func (c *FakeClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+// Advance advances the clock by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	if d > 0 {
+		c.now += d
+	}
+}
@@ -1,4 +1,222 @@
 package vm
 
+import "image"
+
 // CostumeID is the ID of a costume.
 type CostumeID int
+
+// CostumeLimbs is the number of limbs of a costume. Each limb plays its own sequence of pictures.
+const CostumeLimbs = 16
+
+// Costume is a costume resource, the set of animations an actor can play.
+type Costume struct {
+	ID CostumeID
+
+	// Palette maps the colors of the pictures into the colors of the room palette. The actor palette
+	// overrides it for the entries that are set.
+	Palette []byte
+
+	// Mirror tells the animations facing west are drawn as they are stored. Otherwise, they are
+	// drawn flipped, from the pictures facing east.
+	Mirror bool
+
+	// Anims are the animations of the costume, indexed by the animation number multiplied by 4
+	// plus the direction code. Missing animations are nil.
+	Anims []*CostumeAnim
+
+	// Commands are the steps played by the limbs. A step below CostumeCmdFirst draws the picture of
+	// the limb with that number.
+	Commands []byte
+
+	// Pictures are the pictures of each limb, indexed by the command that draws them.
+	Pictures [CostumeLimbs]map[byte]*CostumePicture
+}
+
+// CostumeCmdFirst is the first of the costume commands that do not draw a picture.
+const CostumeCmdFirst = 0x79
+
+const (
+	// CostumeCmdStop and CostumeCmdStart freeze and resume the limb they are assigned to.
+	CostumeCmdStop  = 0x79
+	CostumeCmdStart = 0x7A
+)
+
+// CostumeAnim is an animation of a costume.
+type CostumeAnim struct {
+	// Limbs are the sequences played by each limb, or nil for the limbs the animation leaves as
+	// they were.
+	Limbs [CostumeLimbs]*CostumeLimbAnim
+}
+
+// CostumeLimbAnim is the sequence of commands played by a limb in an animation.
+type CostumeLimbAnim struct {
+	// Hide removes the limb from the actor.
+	Hide bool
+
+	// Start and End are the first and last commands of the sequence.
+	Start int
+	End   int
+
+	// NoLoop makes the limb stay at the last command instead of starting over.
+	NoLoop bool
+}
+
+// CostumePicture is a picture of a costume limb.
+type CostumePicture struct {
+	// Image holds the colors of the costume palette. Color 0 is transparent.
+	Image *image.Paletted
+
+	// RelX and RelY are the offset of the picture respect the actor position, added to the moves of
+	// the limbs drawn before.
+	RelX int
+	RelY int
+
+	// MoveX and MoveY are the offset this picture applies to the limbs drawn after it.
+	MoveX int
+	MoveY int
+}
+
+// limbState is the sequence of commands being played by a limb of an actor.
+type limbState struct {
+	// anim is the animation that set the limb, or -1 if there is none.
+	anim int
+
+	pos    int
+	start  int
+	end    int
+	noLoop bool
+	shown  bool
+}
+
+// actorCostume is the state of the costume animations of an actor.
+type actorCostume struct {
+	// id and facing are the costume and the direction the limbs were decoded for.
+	id     CostumeID
+	facing int
+
+	// pending are the animations started but not applied yet to the limbs.
+	pending []int
+
+	stopped uint16
+	limbs   [CostumeLimbs]limbState
+}
+
+// reset hides all the limbs of the costume.
+func (c *actorCostume) reset() {
+	c.stopped = 0
+	for i := range c.limbs {
+		c.limbs[i] = limbState{anim: -1}
+	}
+}
+
+// decode sets the limbs selected by mask to the sequences of the given animation and direction. The
+// mask selects limb 0 with its most significant bit.
+func (cost *Costume) decode(c *actorCostume, anim, dir int, mask uint16) {
+	idx := anim*4 + dir
+	if idx < 0 || idx >= len(cost.Anims) || cost.Anims[idx] == nil {
+		return
+	}
+	for i, la := range cost.Anims[idx].Limbs {
+		if la == nil || mask&(0x8000>>i) == 0 {
+			continue
+		}
+		limb := &c.limbs[i]
+		if la.Hide {
+			*limb = limbState{anim: anim}
+			continue
+		}
+		switch cost.command(la.Start) {
+		case CostumeCmdStop:
+			c.stopped |= 1 << i
+		case CostumeCmdStart:
+			c.stopped &^= 1 << i
+		default:
+			*limb = limbState{
+				anim:   anim,
+				pos:    la.Start,
+				start:  la.Start,
+				end:    la.End,
+				noLoop: la.NoLoop,
+				shown:  true,
+			}
+		}
+	}
+}
+
+// step advances the limbs of the actor costume to their next command.
+func (cost *Costume) step(c *actorCostume) {
+	for i := range c.limbs {
+		limb := &c.limbs[i]
+		if !limb.shown || c.stopped&(1<<i) != 0 {
+			continue
+		}
+		switch {
+		case limb.pos < limb.end:
+			limb.pos++
+		case !limb.noLoop:
+			limb.pos = limb.start
+		}
+	}
+}
+
+// command returns the command at the given position, or CostumeCmdFirst if it is out of range.
+func (cost *Costume) command(pos int) byte {
+	if pos < 0 || pos >= len(cost.Commands) {
+		return CostumeCmdFirst
+	}
+	return cost.Commands[pos]
+}
+
+// costume returns the costume with the given ID, or nil if it is not available.
+func (e *Engine) costume(id CostumeID) *Costume {
+	if id == 0 {
+		return nil
+	}
+	if cost, ok := e.costumes[id]; ok {
+		return cost
+	}
+	// Missing costumes are remembered, so they are not looked up again on every frame.
+	cost, err := e.rm.GetCostume(id)
+	if err != nil {
+		cost = nil
+	}
+	e.costumes[id] = cost
+	return cost
+}
+
+// animateActors plays the costume animations of the actors of the current room. The animations
+// started since the last frame are applied to the limbs, which are decoded again for the current
+// direction of the actor, and every limb advances one step.
+func (e *Engine) animateActors() {
+	for i := 1; i < MaxActors; i++ {
+		a := &e.actors[i]
+		c := &a.cost
+		cost := e.costume(a.Costume)
+		if a.Room == 0 || a.Room != e.roomID || cost == nil {
+			c.pending = c.pending[:0]
+			continue
+		}
+		dir := newDirToOldDir(a.Facing)
+		if c.id != a.Costume {
+			c.reset()
+			c.id, c.facing = a.Costume, a.Facing
+			cost.decode(c, a.InitFrame, dir, 0xFFFF)
+		}
+		for _, anim := range c.pending {
+			if anim == a.InitFrame {
+				c.reset()
+			}
+			cost.decode(c, anim, dir, 0xFFFF)
+		}
+		c.pending = c.pending[:0]
+		if c.facing != a.Facing {
+			c.facing = a.Facing
+			for j := range c.limbs {
+				if anim := c.limbs[j].anim; anim >= 0 {
+					cost.decode(c, anim, dir, 0x8000>>j)
+				}
+			}
+		}
+		cost.step(c)
+	}
+}
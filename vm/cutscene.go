@@ -0,0 +1,103 @@
+package vm
+
+import "fmt"
+
+// MaxCutscenes is the maximum number of nested cutscenes.
+const MaxCutscenes = 5
+
+// cutscene is an entry of the cutscene stack. The bottom entry is not a cutscene, but the place
+// where the overrides set outside of any cutscene are registered.
+type cutscene struct {
+	// data is the first argument passed to the cutscene, sent back to the end cutscene script.
+	data int
+
+	// cursor and userput are the UI state to restore when the cutscene ends.
+	cursor  int
+	userput int
+
+	// thread is the thread that registered an override point, or nil if there is none.
+	thread *Thread
+
+	// target is the address where the thread jumps if the override point is triggered.
+	target uint16
+}
+
+// BeginCutscene starts a new cutscene on behalf of thread t. The cutscene start script set in
+// VarCutsceneStartScript, if any, is run with the given arguments.
+func (e *Engine) BeginCutscene(t *Thread, args []int) error {
+	if len(e.cutscenes) > MaxCutscenes {
+		return fmt.Errorf("cutscene stack overflow in script %d", t.script.ID)
+	}
+
+	cs := cutscene{
+		cursor:  e.props[PropUICursorVisible],
+		userput: e.props[PropUIUserputEnabled],
+	}
+	if len(args) > 0 {
+		cs.data = args[0]
+	}
+	e.cutscenes = append(e.cutscenes, cs)
+
+	if script := e.words[VarCutsceneStartScript]; script != 0 {
+		return e.StartScript(ScriptID(script), args, false)
+	}
+	return nil
+}
+
+// EndCutscene ends the current cutscene on behalf of thread t. The UI state saved when the cutscene
+// began is restored, and then the cutscene end script set in VarCutsceneEndScript, if any, is run.
+func (e *Engine) EndCutscene(t *Thread) error {
+	if len(e.cutscenes) <= 1 {
+		return fmt.Errorf("cutscene stack underflow in script %d", t.script.ID)
+	}
+
+	cs := e.cutscenes[len(e.cutscenes)-1]
+	e.cutscenes = e.cutscenes[:len(e.cutscenes)-1]
+	e.words[VarOverride] = 0
+	e.props[PropUICursorVisible] = cs.cursor
+	e.props[PropUIUserputEnabled] = cs.userput
+
+	if script := e.words[VarCutsceneEndScript]; script != 0 {
+		return e.StartScript(ScriptID(script), []int{cs.data}, false)
+	}
+	return nil
+}
+
+// BeginOverride registers an override point for the current cutscene. If the cutscene is aborted,
+// thread t will resume its execution at the target address.
+func (e *Engine) BeginOverride(t *Thread, target uint16) {
+	cs := &e.cutscenes[len(e.cutscenes)-1]
+	cs.thread = t
+	cs.target = target
+}
+
+// EndOverride removes the override point of the current cutscene.
+func (e *Engine) EndOverride() {
+	cs := &e.cutscenes[len(e.cutscenes)-1]
+	cs.thread = nil
+	e.words[VarOverride] = 0
+}
+
+// AbortCutscene triggers the override point of the current cutscene, if any. The thread that
+// registered it jumps to the override target and VarOverride is set to signal the abort.
+func (e *Engine) AbortCutscene() {
+	cs := &e.cutscenes[len(e.cutscenes)-1]
+	t := cs.thread
+	if t == nil {
+		return
+	}
+	cs.thread = nil
+	if t.status == ThreadDead {
+		return
+	}
+
+	t.Jump(cs.target)
+	t.status = ThreadRunning
+	t.delay = 0
+	e.words[VarOverride] = 1
+}
+
+// CutsceneDepth returns the number of nested cutscenes currently running.
+func (e *Engine) CutsceneDepth() int {
+	return len(e.cutscenes) - 1
+}
@@ -0,0 +1,59 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCutsceneOverride(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x1A, 0x23, 0x00, 0x02, 0x00, // 0000: VAR_CUTSCENE_START_SCRIPT = 2
+			0x1A, 0x24, 0x00, 0x03, 0x00, // 0005: VAR_CUTSCENE_END_SCRIPT = 3
+			0x40, 0x01, 0x07, 0x00, 0xFF, // 000A: CutScene [7]
+			0x58, 0x01, 0x18, 0x04, 0x00, // 000F: BeginOverride 0018
+			0x80,             // 0014: BreakHere
+			0x18, 0xFC, 0xFF, // 0015: Jump 0014
+			0x58, 0x00, // 0018: EndOverride
+			0xC0,                         // 001A: EndCutScene
+			0x1A, 0x65, 0x00, 0x01, 0x00, // 001B: VAR_101 = 1
+			0xA0, // 0020: StopObjectCode
+		},
+		2: {
+			0x9A, 0x64, 0x00, 0x00, 0x40, // 0000: VAR_100 = LOCAL_0
+			0x2C, 0x04, // 0005: UserputDisable
+			0xA0, // 0007: StopObjectCode
+		},
+		3: {
+			0x9A, 0x66, 0x00, 0x00, 0x40, // 0000: VAR_102 = LOCAL_0
+			0xA0, // 0005: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	eng.SetProperty(vm.PropUIUserputEnabled, 1)
+	eng.WriteWord(vm.VarCutsceneExitKey, 27)
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	assert.Equal(t, 1, eng.CutsceneDepth())
+	assert.Equal(t, 7, eng.ReadWord(100))
+	assert.Equal(t, 0, eng.GetProperty(vm.PropUIUserputEnabled))
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, eng.RunFrame())
+	}
+	assert.True(t, eng.IsScriptRunning(1))
+	assert.Equal(t, 0, eng.ReadWord(101))
+
+	eng.PressKey(27)
+	require.NoError(t, eng.RunFrame())
+
+	assert.False(t, eng.IsScriptRunning(1))
+	assert.Equal(t, 0, eng.CutsceneDepth())
+	assert.Equal(t, 1, eng.ReadWord(101))
+	assert.Equal(t, 7, eng.ReadWord(102))
+	assert.Equal(t, 1, eng.GetProperty(vm.PropUIUserputEnabled))
+}
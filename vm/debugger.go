@@ -0,0 +1,141 @@
+package vm
+
+import (
+	"errors"
+	"slices"
+)
+
+// Breakpoint is a script address where the debugger stops the engine.
+type Breakpoint struct {
+	Script  ScriptID
+	Address uint16
+}
+
+// Debugger controls the execution of an engine that runs in its own goroutine. The engine stops
+// before executing the instruction of a breakpoint, or after executing a single step. While the
+// engine is stopped, its state can be inspected and changed safely.
+type Debugger struct {
+	eng         *Engine
+	breakpoints map[Breakpoint]bool
+
+	// stepping makes the engine stop before the next instruction.
+	stepping bool
+
+	current *Thread
+	stopped chan *Thread
+	resume  chan struct{}
+	done    chan error
+	err     error
+}
+
+// NewDebugger returns a debugger for the given engine, which is stopped before its first
+// instruction once started.
+func NewDebugger(e *Engine) *Debugger {
+	d := &Debugger{
+		eng:         e,
+		breakpoints: make(map[Breakpoint]bool),
+		stepping:    true,
+		stopped:     make(chan *Thread),
+		resume:      make(chan struct{}),
+		done:        make(chan error, 1),
+	}
+	e.SetStepHook(d.hook)
+	return d
+}
+
+// ErrEngineDone is returned when the debugger is asked to resume an engine that is not running.
+var ErrEngineDone = errors.New("the engine is not running")
+
+// Engine returns the engine controlled by the debugger.
+func (d *Debugger) Engine() *Engine {
+	return d.eng
+}
+
+// Start runs the given function, that runs the engine, in its own goroutine. It returns when the
+// engine stops before its first instruction, or when the function returns.
+func (d *Debugger) Start(run func() error) (*Thread, error) {
+	go func() {
+		d.done <- run()
+	}()
+	return d.wait()
+}
+
+// Step resumes the engine to execute one instruction. It returns the thread that will execute the
+// next instruction, or nil and the error returned by the engine if it finished.
+func (d *Debugger) Step() (*Thread, error) {
+	d.stepping = true
+	return d.Continue()
+}
+
+// Continue resumes the engine until it reaches a breakpoint. It returns the thread that stopped at
+// the breakpoint, or nil and the error returned by the engine if it finished.
+func (d *Debugger) Continue() (*Thread, error) {
+	if d.current == nil {
+		return nil, ErrEngineDone
+	}
+	d.resume <- struct{}{}
+	return d.wait()
+}
+
+// Current returns the thread where the engine is stopped, or nil if it is not running.
+func (d *Debugger) Current() *Thread {
+	return d.current
+}
+
+// Err returns the error returned by the engine when it finished, if any.
+func (d *Debugger) Err() error {
+	return d.err
+}
+
+// SetBreakpoint adds a breakpoint at the given address of the script.
+func (d *Debugger) SetBreakpoint(script ScriptID, addr uint16) {
+	d.breakpoints[Breakpoint{script, addr}] = true
+}
+
+// ClearBreakpoint removes the breakpoint at the given address of the script, returning false if
+// there was none.
+func (d *Debugger) ClearBreakpoint(script ScriptID, addr uint16) bool {
+	bp := Breakpoint{script, addr}
+	if !d.breakpoints[bp] {
+		return false
+	}
+	delete(d.breakpoints, bp)
+	return true
+}
+
+// Breakpoints returns the breakpoints sorted by script and address.
+func (d *Debugger) Breakpoints() []Breakpoint {
+	var bps []Breakpoint
+	for bp := range d.breakpoints {
+		bps = append(bps, bp)
+	}
+	slices.SortFunc(bps, func(a, b Breakpoint) int {
+		if a.Script != b.Script {
+			return int(a.Script - b.Script)
+		}
+		return int(a.Address) - int(b.Address)
+	})
+	return bps
+}
+
+func (d *Debugger) wait() (*Thread, error) {
+	select {
+	case t := <-d.stopped:
+		d.current = t
+		return t, nil
+	case err := <-d.done:
+		d.current = nil
+		d.err = err
+		return nil, err
+	}
+}
+
+// hook runs in the goroutine of the engine, which is blocked while it is stopped.
+func (d *Debugger) hook(t *Thread) {
+	if !d.stepping && !d.breakpoints[Breakpoint{t.script.ID, t.Address()}] {
+		return
+	}
+	d.stepping = false
+	d.stopped <- t
+	<-d.resume
+}
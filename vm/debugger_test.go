@@ -0,0 +1,73 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugger(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x1A, 0x64, 0x00, 0x01, 0x00, // 0000: VAR_100 = 1
+			0x0A, 0x02, 0xFF, // 0005: StartScript 2 []
+			0x1A, 0x64, 0x00, 0x02, 0x00, // 0008: VAR_100 = 2
+			0xA0, // 000D: StopObjectCode
+		},
+		2: {
+			0x80,                         // 0000: BreakHere
+			0x1A, 0x65, 0x00, 0x03, 0x00, // 0001: VAR_101 = 3
+			0xA0, // 0006: StopObjectCode
+		},
+	}
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	dbg := vm.NewDebugger(eng)
+	dbg.SetBreakpoint(2, 0x0001)
+	dbg.SetBreakpoint(1, 0x0008)
+	dbg.SetBreakpoint(3, 0x0000)
+	assert.True(t, dbg.ClearBreakpoint(3, 0x0000))
+	assert.False(t, dbg.ClearBreakpoint(3, 0x0000))
+	assert.Equal(t, []vm.Breakpoint{{Script: 1, Address: 0x0008}, {Script: 2, Address: 0x0001}},
+		dbg.Breakpoints())
+
+	th, err := dbg.Start(eng.Run)
+	require.NoError(t, err)
+	assert.Equal(t, vm.ScriptID(1), th.ScriptID())
+	assert.Equal(t, uint16(0x0000), th.Address())
+	assert.Equal(t, 0, eng.ReadWord(100))
+
+	th, err = dbg.Step()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0x0005), th.Address())
+	assert.Equal(t, 1, eng.ReadWord(100))
+
+	th, err = dbg.Step()
+	require.NoError(t, err)
+	assert.Equal(t, vm.ScriptID(2), th.ScriptID())
+	assert.Equal(t, uint16(0x0000), th.Address())
+	assert.Same(t, th, dbg.Current())
+
+	th, err = dbg.Continue()
+	require.NoError(t, err)
+	assert.Equal(t, vm.ScriptID(1), th.ScriptID())
+	assert.Equal(t, uint16(0x0008), th.Address())
+	eng.WriteWord(101, 42)
+
+	th, err = dbg.Continue()
+	require.NoError(t, err)
+	assert.Equal(t, vm.ScriptID(2), th.ScriptID())
+	assert.Equal(t, uint16(0x0001), th.Address())
+	assert.Equal(t, 2, eng.ReadWord(100))
+	assert.Equal(t, 42, eng.ReadWord(101))
+
+	th, err = dbg.Continue()
+	assert.NoError(t, err)
+	assert.Nil(t, th)
+	assert.Nil(t, dbg.Current())
+	assert.Equal(t, 3, eng.ReadWord(101))
+
+	_, err = dbg.Step()
+	assert.ErrorIs(t, err, vm.ErrEngineDone)
+}
@@ -1,27 +1,114 @@
 package vm
 
-import "fmt"
+import (
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"time"
+)
 
 const (
 	MaxWords  = 8192
 	MaxBits   = 32768
 	MaxLocals = 16
+	MaxSlots  = 20
+
+	// DefaultFrameDelay is the number of jiffies between frames when VarTimerNext is not set.
+	DefaultFrameDelay = 4
 )
 
 type Engine struct {
 	rm    ResourceManager
+	fe    Frontend
 	props map[Property]int
 	words []int
 	bits  []byte
+
+	slots     [MaxSlots]*Thread
+	scripts   map[ScriptID]*Script
+	cutscenes []cutscene
+
+	room        *Room
+	boxMatrix   BoxMatrix
+	roomID      RoomID
+	pseudoRooms [0x80]RoomID
+	actors      [MaxActors]Actor
+	objects     map[ObjectID]*Object
+	inventory   []inventoryItem
+	verbs       [MaxVerbs]Verb
+	sentences   []sentence
+	strings     [MaxStrings][]byte
+	charsets    map[CharsetID]*Charset
+	charset     CharsetID
+	costumes    map[CostumeID]*Costume
+
+	textStyles   [numTextSlots]TextStyle
+	textDefaults [numTextSlots]TextStyle
+	texts        []Text
+	textLayer    *image.Paletted
+	message      message
+	keepText     bool
+	haveMsg      int
+	talkDelay    int
+	camera       Camera
+	screenTop    int
+	screenBottom int
+
+	sounds map[SoundID]bool
+	music  SoundID
+
+	store        Store
+	saveLoad     int
+	saveLoadSlot int
+	saveLoadErr  error
+	varsWriter   io.WriteCloser
+	varsReader   io.ReadCloser
+
+	// stepHook is called before a thread executes each instruction.
+	stepHook func(t *Thread)
+	tracer   Tracer
+
+	// lenient logs and skips the instructions that are not implemented instead of failing, if set.
+	lenient *log.Logger
+
+	frameAt time.Duration
+	jiffies int
 }
 
-func NewEngine(rm ResourceManager) *Engine {
-	return &Engine{
-		rm:    rm,
-		props: make(map[Property]int),
-		words: make([]int, MaxWords),
-		bits:  make([]byte, MaxBits/8),
+// NewEngine creates a new engine that loads resources from rm and runs on the given frontend, which
+// also paces its execution.
+func NewEngine(rm ResourceManager, fe Frontend) *Engine {
+	e := &Engine{
+		rm:        rm,
+		fe:        fe,
+		props:     make(map[Property]int),
+		words:     make([]int, MaxWords),
+		bits:      make([]byte, MaxBits/8),
+		scripts:   make(map[ScriptID]*Script),
+		cutscenes: make([]cutscene, 1, MaxCutscenes+1),
+		charsets:  make(map[CharsetID]*Charset),
+		costumes:  make(map[CostumeID]*Costume),
+		textLayer: image.NewPaletted(image.Rect(0, 0, ScreenWidth, ScreenHeight), nil),
+		sounds:    make(map[SoundID]bool),
+		store:     NewMemStore(),
 	}
+	for i := range e.textStyles {
+		e.textStyles[i] = defaultTextStyle()
+		e.textDefaults[i] = defaultTextStyle()
+	}
+	for i := range e.actors {
+		e.actors[i].ID = ActorID(i)
+		e.actors[i].Init(true)
+	}
+	e.objects = make(map[ObjectID]*Object)
+	for id, obj := range rm.GetObjects() {
+		e.objects[id] = &Object{ID: id, Class: obj.Class, Owner: obj.Owner, State: obj.State}
+	}
+	e.seedInventory()
+	e.resetCamera()
+	e.InitScreen(DefaultScreenTop, DefaultScreenBottom)
+	return e
 }
 
 func (e *Engine) GetProperty(prop Property) int {
@@ -33,18 +120,35 @@ func (e *Engine) SetProperty(prop Property, value int) {
 }
 
 func (e *Engine) ReadWord(idx uint16) int {
+	checkVarIndex(SymbolTypeVar, idx, MaxWords)
 	return e.words[idx]
 }
 
 func (e *Engine) WriteWord(idx uint16, value int) {
+	checkVarIndex(SymbolTypeVar, idx, MaxWords)
+	if e.tracer != nil {
+		e.tracer.Trace(VarWriteEvent{Type: SymbolTypeVar, Index: idx, Old: e.words[idx], New: value})
+	}
 	e.words[idx] = value
 }
 
 func (e *Engine) ReadBit(idx uint16) bool {
+	checkVarIndex(SymbolTypeBit, idx, MaxBits)
 	return e.bits[idx/8]&(1<<(idx%8)) != 0
 }
 
 func (e *Engine) WriteBit(idx uint16, value bool) {
+	checkVarIndex(SymbolTypeBit, idx, MaxBits)
+	if e.tracer != nil {
+		ev := VarWriteEvent{Type: SymbolTypeBit, Index: idx}
+		if e.ReadBit(idx) {
+			ev.Old = 1
+		}
+		if value {
+			ev.New = 1
+		}
+		e.tracer.Trace(ev)
+	}
 	if value {
 		e.bits[idx/8] |= 1 << (idx % 8)
 	} else {
@@ -52,13 +156,238 @@ func (e *Engine) WriteBit(idx uint16, value bool) {
 	}
 }
 
+// StartScript starts the script with the given ID in a free slot, passing args as its first local
+// variables. Unless recursive is set, any running instance of the same script is stopped first. The
+// new thread runs immediately until it yields or terminates.
+func (e *Engine) StartScript(id ScriptID, args []int, recursive bool) error {
+	script, err := e.loadScript(id)
+	if err != nil {
+		return err
+	}
+	if !recursive {
+		e.StopScript(id)
+	}
+	return e.startThread(script, args)
+}
+
+// startThread starts a new thread running script in a free slot, passing args as its first local
+// variables. The new thread runs immediately until it yields or terminates.
+func (e *Engine) startThread(script *Script, args []int) error {
+	slot := e.freeSlot()
+	if slot < 0 {
+		return fmt.Errorf("cannot start script %d: no free slots", script.ID)
+	}
+	th := NewThread(script)
+	for i, arg := range args {
+		if i < MaxLocals {
+			th.local[i] = arg
+		}
+	}
+	return e.runNewThread(slot, th)
+}
+
+// StopScript stops all the threads running the script with the given ID. Threads running object
+// code are not affected.
+func (e *Engine) StopScript(id ScriptID) {
+	for i, th := range e.slots {
+		if th != nil && th.object == 0 && th.script.ID == id {
+			e.stopSlot(i)
+		}
+	}
+}
+
+// IsScriptRunning returns true if there is a thread running the script with the given ID.
+func (e *Engine) IsScriptRunning(id ScriptID) bool {
+	for _, th := range e.slots {
+		if th != nil && th.object == 0 && th.script.ID == id && th.status != ThreadDead {
+			return true
+		}
+	}
+	return false
+}
+
+// Jiffies returns the game time in jiffies, as the sum of the frame delays executed so far.
+func (e *Engine) Jiffies() int {
+	return e.jiffies
+}
+
+// RunFrame runs one frame of the engine. It waits on the frontend clock for the number of jiffies
+// requested in VarTimerNext, updates the timer variables, applies the input of the player, wakes up
+// the delayed threads and gives every running thread the chance to execute until it yields or
+// terminates. The game is saved or loaded if requested, and the composed frame is presented by the
+// frontend. The game time advances by the requested jiffies no matter how late the frame starts,
+// so the execution is deterministic.
+func (e *Engine) RunFrame() error {
+	delta := e.words[VarTimerNext]
+	if delta < 1 {
+		delta = DefaultFrameDelay
+	}
+	e.frameAt += time.Duration(delta) * Jiffy
+	if wait := e.frameAt - e.fe.Now(); wait > 0 {
+		e.fe.Sleep(wait)
+	} else {
+		e.frameAt -= wait
+	}
+
+	e.jiffies += delta
+	e.words[VarTimer] = delta
+	e.words[VarTimerTotal] += delta
+	e.words[VarTmr1] += delta
+	e.words[VarTmr2] += delta
+	e.words[VarTmr3] += delta
+	e.updateSounds(delta)
+
+	for _, th := range e.slots {
+		if th != nil && th.status == ThreadPaused {
+			th.delay -= delta
+			if th.delay <= 0 {
+				th.delay = 0
+				th.status = ThreadRunning
+			}
+		}
+	}
+
+	e.words[VarCursorState] = e.props[PropUICursorVisible]
+	e.words[VarUserput] = e.props[PropUIUserputEnabled]
+	for _, ev := range e.fe.PollInput() {
+		if err := e.handleInput(ev); err != nil {
+			return err
+		}
+	}
+	e.updateMessage(delta)
+	e.words[VarHaveMsg] = e.haveMsg
+
+	if err := e.runSentence(); err != nil {
+		return err
+	}
+	for i := range e.slots {
+		if th := e.slots[i]; th != nil && th.status == ThreadRunning {
+			if err := e.runSlot(i); err != nil {
+				return err
+			}
+		}
+	}
+	e.walkActors()
+	e.animateActors()
+	if err := e.moveCamera(); err != nil {
+		return err
+	}
+	e.runSaveLoad()
+	return e.fe.Present(e.Frame())
+}
+
+// Run starts the boot script and runs frames as long as there are running threads.
 func (e *Engine) Run() error {
-	bootscript, err := e.rm.GetScript(1, true)
+	return e.RunTicks(0)
+}
+
+// RunTicks starts the boot script and runs the given number of frames, or less if there are no
+// running threads left. If ticks is not positive, frames are run until no threads are left.
+func (e *Engine) RunTicks(ticks int) error {
+	if err := e.StartScript(1, nil, false); err != nil {
+		return fmt.Errorf("could not start bootscript: %w", err)
+	}
+	for n := 0; (ticks <= 0 || n < ticks) && e.hasThreads(); n++ {
+		if err := e.RunFrame(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LookupScript returns the global script with the given ID, or the local script of the current
+// room.
+func (e *Engine) LookupScript(id ScriptID) (*Script, error) {
+	return e.loadScript(id)
+}
+
+// Slots returns the threads of the script slots, being nil the free ones.
+func (e *Engine) Slots() [MaxSlots]*Thread {
+	return e.slots
+}
+
+// SetTracer sets the tracer that receives the execution events of the engine, or disables the
+// tracing if nil.
+func (e *Engine) SetTracer(t Tracer) {
+	e.tracer = t
+}
+
+// SetLenient enables the lenient mode, where the instructions that are not implemented are logged
+// into the given logger and skipped instead of failing, or disables it if nil.
+func (e *Engine) SetLenient(logger *log.Logger) {
+	e.lenient = logger
+}
+
+// SetStepHook sets a function that is called before a thread executes each instruction, or removes
+// it if nil. The hook may block to pause the execution of the engine.
+func (e *Engine) SetStepHook(hook func(t *Thread)) {
+	e.stepHook = hook
+}
+
+func (e *Engine) loadScript(id ScriptID) (*Script, error) {
+	if id.IsLocal() {
+		var script *Script
+		if e.room != nil {
+			script = e.room.LocalScript(id)
+		}
+		if script == nil {
+			return nil, fmt.Errorf("could not load script %d: not a local script of the current room", id)
+		}
+		return script, nil
+	}
+	if script, ok := e.scripts[id]; ok {
+		return script, nil
+	}
+	script, err := e.rm.GetScript(id, true)
 	if err != nil {
-		return fmt.Errorf("could not load bootscript: %v", err)
+		return nil, fmt.Errorf("could not load script %d: %w", id, err)
 	}
+	e.scripts[id] = script
+	return script, nil
+}
 
-	// TODO: determine what goes next after running bootscript
-	th := NewThread(bootscript)
-	return th.Run(e)
+// runNewThread places the thread in the given free slot and runs it until it yields or terminates.
+func (e *Engine) runNewThread(slot int, th *Thread) error {
+	e.slots[slot] = th
+	if e.tracer != nil {
+		e.tracer.Trace(ScriptStartEvent{Slot: slot, Script: th.script.ID, Object: th.object})
+	}
+	return e.runSlot(slot)
+}
+
+func (e *Engine) runSlot(slot int) error {
+	th := e.slots[slot]
+	err := th.Run(e)
+	if th.status == ThreadDead && e.slots[slot] == th {
+		e.stopSlot(slot)
+	}
+	return err
+}
+
+// stopSlot stops the thread of the given slot and frees the slot.
+func (e *Engine) stopSlot(slot int) {
+	th := e.slots[slot]
+	th.Stop()
+	e.slots[slot] = nil
+	if e.tracer != nil {
+		e.tracer.Trace(ScriptStopEvent{Slot: slot, Script: th.script.ID, Object: th.object})
+	}
+}
+
+func (e *Engine) freeSlot() int {
+	for i, th := range e.slots {
+		if th == nil {
+			return i
+		}
+	}
+	return -1
+}
+
+func (e *Engine) hasThreads() bool {
+	for _, th := range e.slots {
+		if th != nil {
+			return true
+		}
+	}
+	return false
 }
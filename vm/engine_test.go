@@ -0,0 +1,82 @@
+package vm_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/apoloval/scumm-go/vm4/inst"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptResources is a resource manager that serves global scripts from raw bytecode.
+type scriptResources map[vm.ScriptID][]byte
+
+func (r scriptResources) GetRoom(id vm.RoomID, decode bool) (*vm.Room, error) {
+	return nil, fmt.Errorf("unknown room ID %d", id)
+}
+
+func (r scriptResources) GetRoomByName(name vm.RoomName, decode bool) (*vm.Room, error) {
+	return nil, fmt.Errorf("unknown room %s", name)
+}
+
+func (r scriptResources) GetCharset(id vm.CharsetID) (*vm.Charset, error) {
+	return nil, fmt.Errorf("unknown charset ID %d", id)
+}
+
+func (r scriptResources) GetCostume(id vm.CostumeID) (*vm.Costume, error) {
+	return nil, fmt.Errorf("unknown costume ID %d", id)
+}
+
+func (r scriptResources) GetSound(id vm.SoundID) (*vm.Sound, error) {
+	return nil, fmt.Errorf("unknown sound ID %d", id)
+}
+
+func (r scriptResources) GetObjects() map[vm.ObjectID]vm.IndexedObject {
+	return nil
+}
+
+func (r scriptResources) GetObjectRoom(id vm.ObjectID) (vm.RoomID, error) {
+	return 0, fmt.Errorf("unknown object ID %d", id)
+}
+
+func (r scriptResources) GetScript(id vm.ScriptID, decode bool) (*vm.Script, error) {
+	bytecode, ok := r[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown script ID %d", id)
+	}
+	script := &vm.Script{ID: id, Bytecode: bytecode}
+	if decode {
+		return script, script.Decode(inst.Decode)
+	}
+	return script, nil
+}
+
+func TestEngineTimers(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x1A, 0x13, 0x00, 0x05, 0x00, // 0000: VAR_TIMER_NEXT = 5
+			0x2E, 0x1E, 0x00, 0x00, // 0005: Delay 30
+			0x9A, 0x64, 0x00, 0x2F, 0x00, // 0009: VAR_100 = VAR_TIMER_TOTAL
+			0xA0, // 000E: StopObjectCode
+		},
+	}
+
+	clock := new(vm.FakeClock)
+	eng := vm.NewEngine(rm, vm.NewHeadless(clock))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	frames := 0
+	for eng.IsScriptRunning(1) {
+		require.NoError(t, eng.RunFrame())
+		frames++
+	}
+
+	assert.Equal(t, 6, frames)
+	assert.Equal(t, 30, eng.Jiffies())
+	assert.Equal(t, 30, eng.ReadWord(100))
+	assert.Equal(t, 5, eng.ReadWord(vm.VarTimer))
+	assert.Equal(t, 30, eng.ReadWord(vm.VarTmr1))
+	assert.Equal(t, 30*vm.Jiffy, clock.Now())
+}
@@ -33,6 +33,251 @@ type ExecutionContext interface {
 
 	// WriteLocal writes the value of a local variable.
 	WriteLocal(idx uint16, value int)
+
+	// Jump moves the execution of the current thread to the given script address.
+	Jump(addr uint16)
+
+	// BreakHere yields the execution of the current thread until the next frame.
+	BreakHere()
+
+	// Retry yields the execution of the current thread until the next frame, when the current
+	// instruction is executed again.
+	Retry()
+
+	// Delay pauses the execution of the current thread for the given number of jiffies.
+	Delay(jiffies int)
+
+	// Stop terminates the execution of the current thread.
+	Stop()
+
+	// StartScript starts a new thread running the given script with the given arguments.
+	StartScript(id ScriptID, args []int, recursive bool) error
+
+	// BeginCutscene starts a new cutscene with the given arguments.
+	BeginCutscene(args []int) error
+
+	// EndCutscene ends the current cutscene.
+	EndCutscene() error
+
+	// BeginOverride registers an override point for the current cutscene at the given address.
+	BeginOverride(target uint16)
+
+	// EndOverride removes the override point of the current cutscene.
+	EndOverride()
+
+	// LoadRoom switches to the room with the given ID.
+	LoadRoom(id RoomID) error
+
+	// LoadRoomWithEgo switches to the given room placing the ego actor by the given object, and
+	// then moving it to the given position.
+	LoadRoomWithEgo(obj ObjectID, id RoomID, x, y int) error
+
+	// MapPseudoRoom maps the pseudo room with the given ID to the room resource room.
+	MapPseudoRoom(pseudo, room RoomID)
+
+	// Actor returns the actor with the given ID, or nil if the ID is out of range.
+	Actor(id ActorID) *Actor
+
+	// ActorAt returns the topmost actor of the current room at the given position, or nil.
+	ActorAt(x, y int) *Actor
+
+	// ObjectPosition returns the position of the given actor or object.
+	ObjectPosition(id int) (x, y int, ok bool)
+
+	// ClosestActor returns the ID of the actor closest to the given actor or object.
+	ClosestActor(id int) int
+
+	// FaceActorTo turns the given actor to look at the given actor or object.
+	FaceActorTo(a *Actor, id int)
+
+	// PutActorAtObject places the given actor at the walking position of the given object.
+	PutActorAtObject(a *Actor, id int)
+
+	// PutActor places the actor at the given position of its room.
+	PutActor(a *Actor, x, y int)
+
+	// WalkActorTo makes the actor walk to the given position, facing dir at the end if positive.
+	WalkActorTo(a *Actor, x, y int, dir int)
+
+	// WalkActorToObject makes the actor walk to the given actor or object.
+	WalkActorToObject(a *Actor, id int)
+
+	// WalkActorToActor makes the actor walk next to another actor at the given distance.
+	WalkActorToActor(a, other *Actor, dist int)
+
+	// ActorInBox returns true if the actor stands inside the given walk box of the current room.
+	ActorInBox(a *Actor, box int) bool
+
+	// SetBoxFlags sets the flags of the given walk box of the current room.
+	SetBoxFlags(box int, flags BoxFlags)
+
+	// SetBoxScale sets the scale of the given walk box of the current room.
+	SetBoxScale(box int, scale int)
+
+	// CreateBoxMatrix computes again the box matrix of the current room.
+	CreateBoxMatrix()
+
+	// Object returns the runtime state of the object with the given ID.
+	Object(id ObjectID) Object
+
+	// SetObjectState sets the state of the given object.
+	SetObjectState(id ObjectID, state ObjectState)
+
+	// SetObjectName sets the name of the object.
+	SetObjectName(id ObjectID, name string)
+
+	// SetClass sets or clears the class with the given number in the given object or actor.
+	SetClass(id ObjectID, class ObjectClass, set bool)
+
+	// HasClass returns true if the given object or actor has the class with the given number.
+	HasClass(id ObjectID, class ObjectClass) bool
+
+	// PickUpObject gives the object to the ego actor.
+	PickUpObject(id ObjectID) error
+
+	// SetObjectOwner changes the owner of the object, updating the inventory.
+	SetObjectOwner(id ObjectID, owner ObjectOwner) error
+
+	// InventoryCount returns the number of objects owned by the given owner.
+	InventoryCount(owner ObjectOwner) int
+
+	// FindInventory returns the n-th object owned by the given owner, or 0 if there is none.
+	FindInventory(owner ObjectOwner, n int) ObjectID
+
+	// Verb returns the active verb with the given ID, or nil if there is none.
+	Verb(id VerbID) *Verb
+
+	// NewVerb allocates and resets the verb with the given ID, or returns nil if the table is full.
+	NewVerb(id VerbID) *Verb
+
+	// DeleteVerb removes the active verb with the given ID.
+	DeleteVerb(id VerbID)
+
+	// LayoutVerb updates the size of the verb after changing its name or image.
+	LayoutVerb(v *Verb)
+
+	// SaveVerbs moves the active verbs in the given range into the given group.
+	SaveVerbs(start, end VerbID, group int)
+
+	// RestoreVerbs brings back the verbs in the given range saved into the given group.
+	RestoreVerbs(start, end VerbID, group int)
+
+	// DeleteVerbs removes the verbs in the given range saved into the given group.
+	DeleteVerbs(start, end VerbID, group int)
+
+	// FindObject returns the ID of the object of the current room at the given position, or 0.
+	FindObject(x, y int) ObjectID
+
+	// Distance returns the distance between two actors or objects of the current room.
+	Distance(a, b int) int
+
+	// DoSentence pushes a sentence to be executed by the sentence script.
+	DoSentence(verb VerbID, objA, objB ObjectID) error
+
+	// StopSentence discards the pending sentences and stops the sentence script.
+	StopSentence()
+
+	// IsSentenceRunning returns true if there are pending sentences or the sentence script runs.
+	IsSentenceRunning() bool
+
+	// VerbEntrypoint returns the offset of the object code for the given verb, or 0 if there is none.
+	VerbEntrypoint(id ObjectID, verb VerbID) (int, error)
+
+	// StartObject starts a new thread running the object code for the given verb.
+	StartObject(id ObjectID, verb VerbID, args []int, recursive bool) error
+
+	// StopObjectScript stops all the threads running the code of the given object.
+	StopObjectScript(id ObjectID)
+
+	// NewString allocates the string slot with the given size, or frees it if size is 0.
+	NewString(id StringID, size int) error
+
+	// LoadString sets the content of the string slot to the given text.
+	LoadString(id StringID, text string) error
+
+	// CopyString copies the content of the string slot src into dst.
+	CopyString(dst, src StringID) error
+
+	// WriteChar writes a character at the given position of the string slot.
+	WriteChar(id StringID, idx int, ch byte) error
+
+	// ReadChar returns the character at the given position of the string slot.
+	ReadChar(id StringID, idx int) (byte, error)
+
+	// ReadString returns the text of the string slot.
+	ReadString(id StringID) string
+
+	// StringWidth returns the width of the text when printed with the current charset.
+	StringWidth(text string) int
+
+	// InitCharset makes the charset with the given ID the current one.
+	InitCharset(id CharsetID) error
+
+	// LoadTextStyle resets the style of the given text slot to its defaults and returns it.
+	LoadTextStyle(slot TextSlot) *TextStyle
+
+	// SaveTextStyle makes the current style of the given text slot its default.
+	SaveTextStyle(slot TextSlot)
+
+	// Print shows a text with the style of the text slot of the given actor.
+	Print(actor int, text string) error
+
+	// SetCameraAt places the camera at the given position right away.
+	SetCameraAt(x int) error
+
+	// PanCameraTo makes the camera scroll to the given position.
+	PanCameraTo(x int)
+
+	// ActorFollowCamera makes the camera follow the given actor.
+	ActorFollowCamera(a *Actor) error
+
+	// SetScrollLimits sets the range of positions of the camera.
+	SetScrollLimits(minX, maxX int)
+
+	// IsCameraMoving returns true if the camera has not reached its destination yet.
+	IsCameraMoving() bool
+
+	// DrawObject shows the object of the current room, moving it first unless x is 0xFF.
+	DrawObject(id ObjectID, x, y int)
+
+	// InitScreen sets the rows of the screen where the room is shown.
+	InitScreen(top, bottom int)
+
+	// StartSound starts playing the given sound.
+	StartSound(id SoundID) error
+
+	// StopSound stops playing the given sound.
+	StopSound(id SoundID)
+
+	// StartMusic starts playing the given sound as the music.
+	StartMusic(id SoundID) error
+
+	// StopMusic stops playing the music and the rest of sounds.
+	StopMusic()
+
+	// IsSoundRunning returns true if the given sound is being played.
+	IsSoundRunning(id SoundID) bool
+
+	// SaveLoadGame requests the game to be saved into or loaded from the given slot.
+	SaveLoadGame(op, slot int)
+
+	// OpenVarsFile opens the file where the variables are written, or read from if write is not set.
+	OpenVarsFile(name string, write bool) error
+
+	// CloseVarsFile closes the file opened by OpenVarsFile.
+	CloseVarsFile() error
+
+	// WriteVarsFile writes the given values of variables to the open vars file.
+	WriteVarsFile(values []int) error
+
+	// ReadVarsFile reads the values of n variables from the open vars file, or nil if there is none.
+	ReadVarsFile(n int) ([]int, error)
+
+	// WriteStringsFile writes the string slots in the given range to the open vars file.
+	WriteStringsFile(first, last StringID) error
+
+	// ReadStringsFile reads the string slots in the given range from the open vars file.
+	ReadStringsFile(first, last StringID) error
 }
 
 func ExecContextFrom(e *Engine, t *Thread) ExecutionContext {
@@ -43,3 +288,32 @@ type executionContext struct {
 	*Engine
 	*Thread
 }
+
+func (ctx *executionContext) WriteLocal(idx uint16, value int) {
+	if ctx.tracer != nil {
+		ctx.tracer.Trace(VarWriteEvent{
+			Type:   SymbolTypeLocal,
+			Index:  idx,
+			Script: ctx.script.ID,
+			Old:    ctx.Thread.ReadLocal(idx),
+			New:    value,
+		})
+	}
+	ctx.Thread.WriteLocal(idx, value)
+}
+
+func (ctx *executionContext) BeginCutscene(args []int) error {
+	return ctx.Engine.BeginCutscene(ctx.Thread, args)
+}
+
+func (ctx *executionContext) EndCutscene() error {
+	return ctx.Engine.EndCutscene(ctx.Thread)
+}
+
+func (ctx *executionContext) Print(actor int, text string) error {
+	return ctx.Engine.Print(ctx.Thread, actor, text)
+}
+
+func (ctx *executionContext) BeginOverride(target uint16) {
+	ctx.Engine.BeginOverride(ctx.Thread, target)
+}
@@ -0,0 +1,120 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotImplemented is the error of the instructions whose execution is not implemented.
+var ErrNotImplemented = errors.New("not implemented")
+
+// VarIndexError is the error of an access to a variable whose index is out of range.
+type VarIndexError struct {
+	Type  SymbolType
+	Index uint16
+}
+
+func (e *VarIndexError) Error() string {
+	return fmt.Sprintf("%s index %d out of range", e.Type, e.Index)
+}
+
+// checkVarIndex panics with a VarIndexError if the index is not below n, so the execution of the
+// instruction accessing the variable fails instead of crashing the engine.
+func checkVarIndex(typ SymbolType, idx uint16, n int) {
+	if int(idx) >= n {
+		panic(&VarIndexError{Type: typ, Index: idx})
+	}
+}
+
+// ExecError is an error raised while a thread executes an instruction.
+type ExecError struct {
+	Slot        int
+	Script      ScriptID
+	Object      ObjectID
+	Address     uint16
+	Instruction string
+	Err         error
+
+	// Backtrace is the state of the active slots when the error was raised.
+	Backtrace []SlotTrace
+}
+
+// SlotTrace is the state of an active slot, as reported in the backtrace of an execution error.
+type SlotTrace struct {
+	Slot    int
+	Script  ScriptID
+	Object  ObjectID
+	Address uint16
+	Status  ThreadStatus
+}
+
+func (e *ExecError) Error() string {
+	return fmt.Sprintf("error executing %s at %04X (%s): %v",
+		codeName(e.Script, e.Object), e.Address, e.Instruction, e.Err)
+}
+
+func (e *ExecError) Unwrap() error {
+	return e.Err
+}
+
+// Report returns a description of the error followed by the backtrace of the active slots, one per
+// line, where the slot that raised the error is marked with an asterisk.
+func (e *ExecError) Report() string {
+	var b strings.Builder
+	b.WriteString(e.Error())
+	b.WriteString("\nActive slots:")
+	for _, st := range e.Backtrace {
+		marker := " "
+		if st.Slot == e.Slot {
+			marker = "*"
+		}
+		fmt.Fprintf(&b, "\n%s %2d: %s at %04X", marker, st.Slot, codeName(st.Script, st.Object),
+			st.Address)
+		if st.Status == ThreadPaused {
+			b.WriteString(" (paused)")
+		}
+	}
+	return b.String()
+}
+
+func codeName(script ScriptID, obj ObjectID) string {
+	if obj != 0 {
+		return fmt.Sprintf("object %d", obj)
+	}
+	return fmt.Sprintf("script %d", script)
+}
+
+// execError returns an execution error raised by the instruction at the given address of the
+// thread, unless err already is an execution error raised by a nested thread.
+func (e *Engine) execError(t *Thread, addr uint16, inst Instruction, err error) error {
+	var nested *ExecError
+	if errors.As(err, &nested) {
+		return err
+	}
+	ee := &ExecError{
+		Slot:        -1,
+		Script:      t.script.ID,
+		Object:      t.object,
+		Address:     addr,
+		Instruction: DisplayInstruction(t.symbols, inst),
+		Err:         err,
+	}
+	for i, th := range e.slots {
+		if th == nil {
+			continue
+		}
+		st := SlotTrace{
+			Slot:    i,
+			Script:  th.script.ID,
+			Object:  th.object,
+			Address: th.Address(),
+			Status:  th.status,
+		}
+		if th == t {
+			ee.Slot, st.Address = i, addr
+		}
+		ee.Backtrace = append(ee.Backtrace, st)
+	}
+	return ee
+}
@@ -0,0 +1,109 @@
+package vm_test
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecError(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x1A, 0x64, 0x00, 0x01, 0x00, // 0000: VAR_100 = 1
+			0x0A, 0x02, 0xFF, // 0005: StartScript 2 []
+			0x6B, 0x07, 0x00, // 0008: Debug 7
+			0x1A, 0x64, 0x00, 0x02, 0x00, // 000B: VAR_100 = 2
+		},
+		2: {
+			0x80,             // 0000: BreakHere
+			0x18, 0x10, 0x00, // 0001: Jump +16
+		},
+	}
+
+	t.Run("Strict", func(t *testing.T) {
+		eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+		err := eng.StartScript(1, nil, false)
+		assert.ErrorIs(t, err, vm.ErrNotImplemented)
+
+		var ee *vm.ExecError
+		require.ErrorAs(t, err, &ee)
+		assert.Equal(t, 0, ee.Slot)
+		assert.Equal(t, vm.ScriptID(1), ee.Script)
+		assert.Equal(t, uint16(0x0008), ee.Address)
+		assert.Equal(t, []vm.SlotTrace{
+			{Slot: 0, Script: 1, Address: 0x0008, Status: vm.ThreadRunning},
+			{Slot: 1, Script: 2, Address: 0x0001, Status: vm.ThreadRunning},
+		}, ee.Backtrace)
+		assert.Equal(t, "error executing script 1 at 0008 (DEBUG   7): not implemented\n"+
+			"Active slots:\n"+
+			"*  0: script 1 at 0008\n"+
+			"   1: script 2 at 0001", ee.Report())
+		assert.Equal(t, 1, eng.ReadWord(100))
+	})
+
+	t.Run("Lenient", func(t *testing.T) {
+		var logged bytes.Buffer
+		eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+		eng.SetLenient(log.New(&logged, "", 0))
+		require.NoError(t, eng.StartScript(1, nil, false))
+		assert.Equal(t, "skipping script 1 at 0008 (DEBUG   7): not implemented\n", logged.String())
+		assert.Equal(t, 2, eng.ReadWord(100))
+		assert.False(t, eng.IsScriptRunning(1))
+
+		err := eng.RunFrame()
+		var ee *vm.ExecError
+		require.ErrorAs(t, err, &ee)
+		assert.Equal(t, 1, ee.Slot)
+		assert.Equal(t, vm.ScriptID(2), ee.Script)
+		assert.Equal(t, uint16(0x0001), ee.Address)
+		assert.ErrorContains(t, err, "invalid jump to address 0014 in script 2")
+		assert.Len(t, ee.Backtrace, 1)
+	})
+}
+
+func TestVarIndexError(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x1A, 0x00, 0x20, 0x0A, 0x00, 0x07, 0x00, // 0000: VAR_0[10] = 7
+			0x1A, 0x64, 0x00, 0x00, 0x20, // 0007: VAR_100 = 8192
+			0x1A, 0x00, 0x20, 0x64, 0x20, 0x01, 0x00, // 000C: VAR_0[VAR_100] = 1
+			0xA0, // 0013: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	err := eng.StartScript(1, nil, false)
+
+	var ee *vm.ExecError
+	require.ErrorAs(t, err, &ee)
+	assert.Equal(t, uint16(0x000C), ee.Address)
+	var ie *vm.VarIndexError
+	require.ErrorAs(t, err, &ie)
+	assert.Equal(t, vm.VarIndexError{Type: vm.SymbolTypeVar, Index: 8192}, *ie)
+	assert.Equal(t, 7, eng.ReadWord(10))
+}
+
+type panicInst struct {
+	value any
+}
+
+func (inst panicInst) Execute(ctx vm.ExecutionContext) { panic(inst.value) }
+
+type outOfRangeInst struct {
+	values []int
+}
+
+func (inst outOfRangeInst) Execute(ctx vm.ExecutionContext) { _ = inst.values[1] }
+
+func TestExecute(t *testing.T) {
+	err := errors.New("failed")
+	assert.Equal(t, err, vm.Execute(nil, panicInst{err}))
+	assert.ErrorIs(t, vm.Execute(nil, struct{}{}), vm.ErrNotImplemented)
+	assert.Panics(t, func() { vm.Execute(nil, panicInst{"bug"}) })
+	assert.Panics(t, func() { vm.Execute(nil, outOfRangeInst{}) })
+}
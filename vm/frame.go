@@ -0,0 +1,201 @@
+package vm
+
+import (
+	"image"
+	"image/color"
+	"slices"
+)
+
+// TransparentColor is the color of the pixels of object images that are not drawn.
+const TransparentColor = 0xFF
+
+const (
+	// DefaultScreenTop and DefaultScreenBottom are the rows of the screen where the room is shown
+	// until the scripts set them.
+	DefaultScreenTop    = 16
+	DefaultScreenBottom = 144
+)
+
+// defaultPalette is the palette of the frames when the room has none: the EGA colors, and black for
+// the rest.
+var defaultPalette = func() color.Palette {
+	pal := make(color.Palette, 256)
+	for i := range pal {
+		pal[i] = color.RGBA{A: 0xFF}
+	}
+	copy(pal, ColorPaletteEGA)
+	return pal
+}()
+
+// InitScreen sets the rows of the screen where the room is shown, from top to bottom excluded. The
+// rows below are left to the verbs.
+func (e *Engine) InitScreen(top, bottom int) {
+	e.screenTop = min(max(top, 0), ScreenHeight)
+	e.screenBottom = min(max(bottom, e.screenTop), ScreenHeight)
+}
+
+// Frame composes the screen as it is seen by the player. It draws the part of the room shown by
+// the camera with its visible objects and the actors sorted by their vertical position, masked by
+// the z-planes of the room. Then, the verbs and the texts are drawn on top. The frame uses the
+// palette of the room.
+func (e *Engine) Frame() *image.Paletted {
+	pal := defaultPalette
+	if e.room != nil && len(e.room.Palette) > 0 {
+		pal = e.room.Palette
+	}
+	frame := image.NewPaletted(image.Rect(0, 0, ScreenWidth, ScreenHeight), pal)
+	if e.room != nil {
+		view := image.Rect(0, e.screenTop, ScreenWidth, e.screenBottom)
+		e.drawRoom(frame.SubImage(view).(*image.Paletted))
+	}
+	e.drawVerbs(frame)
+	for i, c := range e.textLayer.Pix {
+		if c != 0 {
+			frame.Pix[i] = c
+		}
+	}
+	return frame
+}
+
+// drawRoom draws the current room into the view, whose top row shows the top of the room.
+func (e *Engine) drawRoom(view *image.Paletted) {
+	left, top := e.ScreenLeft(), view.Rect.Min.Y
+	if bg := e.room.Background; bg != nil {
+		for y := view.Rect.Min.Y; y < view.Rect.Max.Y; y++ {
+			for x := view.Rect.Min.X; x < view.Rect.Max.X; x++ {
+				if p := image.Pt(x+left, y-top); p.In(bg.Rect) {
+					view.SetColorIndex(x, y, bg.ColorIndexAt(p.X, p.Y))
+				}
+			}
+		}
+	}
+
+	// The objects are drawn from last to first, so the first ones are on top.
+	for i := len(e.room.Objects) - 1; i >= 0; i-- {
+		ro := &e.room.Objects[i]
+		if ro.Image == nil || e.Object(ro.ID).State == 0 || !e.objectVisible(i) {
+			continue
+		}
+		drawImage(view, ro.Image, image.Pt(ro.X-left, ro.Y+top), TransparentColor)
+	}
+
+	var actors []*Actor
+	for i := 1; i < MaxActors; i++ {
+		if a := &e.actors[i]; a.Room != 0 && a.Room == e.roomID && e.costume(a.Costume) != nil {
+			actors = append(actors, a)
+		}
+	}
+	slices.SortStableFunc(actors, func(a, b *Actor) int { return a.Y - b.Y })
+	for _, a := range actors {
+		e.drawActor(view, a)
+	}
+}
+
+// drawActor draws the limbs of the actor costume, scaled by the actor scale and hidden behind the
+// z-plane selected by the mask of its walk box.
+func (e *Engine) drawActor(view *image.Paletted, a *Actor) {
+	cost := e.costume(a.Costume)
+	left, top := e.ScreenLeft(), view.Rect.Min.Y
+	flip := newDirToOldDir(a.Facing) == 0 && !cost.Mirror
+	var zplane *image.Alpha
+	if a.WalkBox >= 0 && a.WalkBox < len(e.room.Boxes) {
+		if mask := int(e.room.Boxes[a.WalkBox].Mask); mask > 0 && mask <= len(e.room.ZPlanes) {
+			zplane = e.room.ZPlanes[mask-1]
+		}
+	}
+	palette := func(c uint8) uint8 {
+		if int(c) < len(a.Palette) && a.Palette[c] != 0xFF {
+			return uint8(a.Palette[c])
+		}
+		if int(c) < len(cost.Palette) {
+			return cost.Palette[c]
+		}
+		return c
+	}
+
+	var movex, movey int
+	for i, limb := range a.cost.limbs {
+		if !limb.shown {
+			continue
+		}
+		pic := cost.Pictures[i][cost.command(limb.pos)]
+		if pic == nil {
+			continue
+		}
+		relx, rely := movex+pic.RelX, movey+pic.RelY
+		movex += pic.MoveX
+		movey -= pic.MoveY
+
+		b := pic.Image.Rect
+		w, h := b.Dx()*a.ScaleX/0xFF, b.Dy()*a.ScaleY/0xFF
+		x0 := a.X - left + relx*a.ScaleX/0xFF
+		if flip {
+			x0 = a.X - left - relx*a.ScaleX/0xFF - w
+		}
+		y0 := a.Y - a.Elevation + top + rely*a.ScaleY/0xFF
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				sx := x * 0xFF / a.ScaleX
+				if flip {
+					sx = b.Dx() - 1 - sx
+				}
+				c := pic.Image.ColorIndexAt(b.Min.X+sx, b.Min.Y+y*0xFF/a.ScaleY)
+				if c == 0 {
+					continue
+				}
+				p := image.Pt(x0+x, y0+y)
+				if zplane != nil && zplane.AlphaAt(p.X+left, p.Y-top).A != 0 {
+					continue
+				}
+				view.SetColorIndex(p.X, p.Y, palette(c))
+			}
+		}
+	}
+}
+
+// drawVerbs draws the visible verbs. Text verbs are drawn with the current charset, and verbs with
+// image with the image of their object in the current room.
+func (e *Engine) drawVerbs(frame *image.Paletted) {
+	cs := e.Charset()
+	for i := range e.verbs {
+		v := &e.verbs[i]
+		if !v.Visible() {
+			continue
+		}
+		left, top, _, _ := v.Bounds()
+		if v.ImageObject != 0 {
+			if e.room != nil {
+				if ro := e.room.Object(v.ImageObject); ro != nil && ro.Image != nil {
+					drawImage(frame, ro.Image, image.Pt(left, top), TransparentColor)
+				}
+			}
+			continue
+		}
+		if cs == nil {
+			continue
+		}
+		fg := v.Color
+		if v.Mode == VerbModeDim {
+			fg = v.DimColor
+		}
+		cs := *cs
+		cs.ColorMap[0] = byte(fg)
+		loc := image.Pt(left, top)
+		for i := 0; i < len(v.Name); i++ {
+			loc.X += cs.PrintChar(rune(v.Name[i]), frame, loc)
+		}
+	}
+}
+
+// drawImage draws the image into dst with its top left corner at the given point, skipping the
+// pixels of the transparent color.
+func drawImage(dst, src *image.Paletted, at image.Point, transparent uint8) {
+	b := src.Rect
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if c := src.ColorIndexAt(x, y); c != transparent {
+				dst.SetColorIndex(at.X+x-b.Min.X, at.Y+y-b.Min.Y, c)
+			}
+		}
+	}
+}
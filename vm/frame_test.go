@@ -0,0 +1,113 @@
+package vm_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func filledImage(w, h int, c uint8) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, w, h), nil)
+	for i := range img.Pix {
+		img.Pix[i] = c
+	}
+	return img
+}
+
+func TestFrame(t *testing.T) {
+	palette := make(color.Palette, 256)
+	for i := range palette {
+		palette[i] = color.Gray{Y: uint8(i)}
+	}
+	background := filledImage(640, 128, 1)
+	for y := 0; y < 128; y++ {
+		for x := 320; x < 640; x++ {
+			background.SetColorIndex(x, y, 2)
+		}
+	}
+	zplane := image.NewAlpha(image.Rect(0, 0, 640, 128))
+	for y := 0; y < 128; y++ {
+		for x := 0; x < 40; x++ {
+			zplane.SetAlpha(x, y, color.Alpha{A: 0xFF})
+		}
+	}
+	costume := &vm.Costume{
+		Palette:  []byte{0, 9},
+		Anims:    make([]*vm.CostumeAnim, 8),
+		Commands: []byte{0x00},
+	}
+	costume.Anims[6] = &vm.CostumeAnim{}
+	costume.Anims[6].Limbs[0] = &vm.CostumeLimbAnim{Start: 0, End: 0}
+	costume.Pictures[0] = map[byte]*vm.CostumePicture{
+		0: {Image: filledImage(4, 4, 1), RelX: -2, RelY: -4},
+	}
+	glyph := &vm.Character{Width: 2, Height: 2, Glyph: []byte{0xF0}}
+	charset := &vm.Charset{BitsPerPixel: 1, FontHeight: 2}
+	charset.Characters['A'] = glyph
+
+	rm := gameResources{
+		scriptResources: scriptResources{},
+		rooms: map[vm.RoomID]vm.Room{
+			10: {
+				Width:      640,
+				Palette:    palette,
+				Background: background,
+				ZPlanes:    []*image.Alpha{zplane},
+				Boxes: []vm.Box{{
+					UL: vm.Point{X: 0, Y: 0}, UR: vm.Point{X: 639, Y: 0},
+					LR: vm.Point{X: 639, Y: 127}, LL: vm.Point{X: 0, Y: 127},
+					Mask: 1,
+				}},
+				Objects: []vm.RoomObject{
+					{ID: 100, X: 16, Y: 8, Width: 8, Height: 8, Image: filledImage(8, 8, 4)},
+					{ID: 101, X: 16, Y: 8, Width: 8, Height: 8, Image: filledImage(8, 8, 5)},
+				},
+			},
+		},
+		costumes: map[vm.CostumeID]*vm.Costume{1: costume},
+		charsets: map[vm.CharsetID]*vm.Charset{0: charset},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.LoadRoom(10))
+	eng.SetObjectState(101, 1)
+	for id, x := range map[vm.ActorID]int{1: 50, 2: 30, 3: 52} {
+		a := eng.Actor(id)
+		a.Costume, a.Room = 1, 10
+		eng.PutActor(a, x, 100+int(id))
+	}
+	eng.Actor(3).Palette[1] = 12
+	v := eng.NewVerb(1)
+	v.Name, v.X, v.Y, v.Color, v.Mode = "A", 10, 180, 14, vm.VerbModeOn
+	require.NoError(t, eng.Print(nil, 0xFE, "A"))
+	require.NoError(t, eng.RunFrame())
+
+	frame := eng.Frame()
+	assert.Equal(t, image.Rect(0, 0, vm.ScreenWidth, vm.ScreenHeight), frame.Rect)
+	assert.Equal(t, palette, frame.Palette)
+	assert.Equal(t, uint8(0), frame.ColorIndexAt(0, 15), "above the room")
+	assert.Equal(t, uint8(1), frame.ColorIndexAt(0, 16), "room background")
+	assert.Equal(t, uint8(0), frame.ColorIndexAt(0, 144), "below the room")
+	assert.Equal(t, uint8(5), frame.ColorIndexAt(16, 24), "object 101")
+
+	eng.DrawObject(100, 0xFF, 0)
+	assert.Equal(t, vm.ObjectState(1), eng.Object(100).State)
+	assert.Equal(t, vm.ObjectState(0), eng.Object(101).State)
+	frame = eng.Frame()
+	assert.Equal(t, uint8(4), frame.ColorIndexAt(16, 24), "object 100")
+
+	assert.Equal(t, uint8(9), frame.ColorIndexAt(48, 113), "actor 1")
+	assert.Equal(t, uint8(12), frame.ColorIndexAt(51, 115), "actor 3 in front of actor 1")
+	assert.Equal(t, uint8(1), frame.ColorIndexAt(29, 115), "actor 2 behind the z-plane")
+	assert.Equal(t, uint8(14), frame.ColorIndexAt(10, 180), "verb")
+	assert.Equal(t, uint8(15), frame.ColorIndexAt(2, 5), "text")
+
+	require.NoError(t, eng.SetCameraAt(480))
+	frame = eng.Frame()
+	assert.Equal(t, uint8(2), frame.ColorIndexAt(0, 16))
+	assert.Equal(t, uint8(2), frame.ColorIndexAt(48, 113))
+}
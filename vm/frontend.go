@@ -0,0 +1,85 @@
+package vm
+
+import "image"
+
+// Frontend is the outside world of the engine. It shows the frames, provides the input of the
+// player, plays the sounds and, as the clock of the engine, paces its execution.
+type Frontend interface {
+	Clock
+
+	// Present shows a frame composed by the engine.
+	Present(frame *image.Paletted) error
+
+	// PollInput returns the input events received since the last call.
+	PollInput() []InputEvent
+
+	// PlaySound starts playing the given sound.
+	PlaySound(id SoundID) error
+
+	// StopSound stops playing the given sound.
+	StopSound(id SoundID)
+
+	// IsSoundPlaying returns true if the given sound was played and it did not finish yet.
+	IsSoundPlaying(id SoundID) bool
+}
+
+// Headless is a frontend without display nor audio. It keeps the last frame presented, takes the
+// input from the events pushed to it and remembers the sounds being played.
+type Headless struct {
+	Clock
+
+	frame  *image.Paletted
+	frames int
+	input  []InputEvent
+	sounds map[SoundID]bool
+}
+
+// NewHeadless returns a headless frontend that paces the engine with the given clock.
+func NewHeadless(clock Clock) *Headless {
+	return &Headless{Clock: clock, sounds: make(map[SoundID]bool)}
+}
+
+// Present implements the Frontend interface.
+func (h *Headless) Present(frame *image.Paletted) error {
+	h.frame = frame
+	h.frames++
+	return nil
+}
+
+// PollInput implements the Frontend interface.
+func (h *Headless) PollInput() []InputEvent {
+	input := h.input
+	h.input = nil
+	return input
+}
+
+// PlaySound implements the Frontend interface.
+func (h *Headless) PlaySound(id SoundID) error {
+	h.sounds[id] = true
+	return nil
+}
+
+// StopSound implements the Frontend interface.
+func (h *Headless) StopSound(id SoundID) {
+	delete(h.sounds, id)
+}
+
+// Frame returns the last frame presented, or nil if there is none.
+func (h *Headless) Frame() *image.Paletted {
+	return h.frame
+}
+
+// Frames returns the number of frames presented.
+func (h *Headless) Frames() int {
+	return h.frames
+}
+
+// PushInput queues input events to be polled by the engine.
+func (h *Headless) PushInput(events ...InputEvent) {
+	h.input = append(h.input, events...)
+}
+
+// IsSoundPlaying implements the Frontend interface. Sounds never finish by themselves.
+func (h *Headless) IsSoundPlaying(id SoundID) bool {
+	return h.sounds[id]
+}
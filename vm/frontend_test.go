@@ -0,0 +1,63 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadlessFrontend(t *testing.T) {
+	rm := gameResources{
+		scriptResources: scriptResources{},
+		rooms: map[vm.RoomID]vm.Room{
+			10: {Width: 640},
+		},
+	}
+
+	fe := vm.NewHeadless(new(vm.FakeClock))
+	eng := vm.NewEngine(rm, fe)
+	require.NoError(t, eng.LoadRoom(10))
+	require.NoError(t, eng.SetCameraAt(480))
+
+	fe.PushInput(vm.InputEvent{Kind: vm.InputMouseMove, X: 100, Y: 50})
+	require.NoError(t, eng.RunFrame())
+	assert.Equal(t, 100, eng.ReadWord(vm.VarMouseX))
+	assert.Equal(t, 50, eng.ReadWord(vm.VarMouseY))
+	assert.Equal(t, 420, eng.ReadWord(vm.VarVirtMouseX))
+	assert.Equal(t, 34, eng.ReadWord(vm.VarVirtMouseY))
+
+	require.NoError(t, eng.RunFrame())
+	assert.Equal(t, 2, fe.Frames())
+	require.NotNil(t, fe.Frame())
+	assert.Equal(t, vm.ScreenWidth, fe.Frame().Rect.Dx())
+	assert.Equal(t, 8*vm.Jiffy, fe.Now())
+
+	require.NoError(t, fe.PlaySound(3))
+	assert.True(t, fe.IsSoundPlaying(3))
+	fe.StopSound(3)
+	assert.False(t, fe.IsSoundPlaying(3))
+}
+
+func TestRunTicks(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x80,             // 0000: BreakHere
+			0x18, 0xFC, 0xFF, // 0001: Jump 0000
+		},
+		2: {
+			0x80, // 0000: BreakHere
+			0xA0, // 0001: StopObjectCode
+		},
+	}
+
+	fe := vm.NewHeadless(new(vm.FakeClock))
+	require.NoError(t, vm.NewEngine(rm, fe).RunTicks(3))
+	assert.Equal(t, 3, fe.Frames())
+
+	rm[1] = rm[2]
+	fe = vm.NewHeadless(new(vm.FakeClock))
+	require.NoError(t, vm.NewEngine(rm, fe).RunTicks(3))
+	assert.Equal(t, 1, fe.Frames())
+}
@@ -0,0 +1,135 @@
+package vm
+
+// InputEventKind is the kind of an input event.
+type InputEventKind int
+
+const (
+	// InputMouseMove is the event of the mouse moving to a position of the screen.
+	InputMouseMove InputEventKind = iota + 1
+
+	// InputLeftClick and InputRightClick are the events of a mouse button pressed at a position of
+	// the screen.
+	InputLeftClick
+	InputRightClick
+
+	// InputKeyPress is the event of a key pressed.
+	InputKeyPress
+)
+
+// InputEvent is an input of the player.
+type InputEvent struct {
+	Kind InputEventKind
+
+	// X and Y are the position of the mouse on screen for mouse events.
+	X int
+	Y int
+
+	// Key is the code of the key pressed for key events, as seen by the scripts.
+	Key int
+}
+
+// ClickArea is the part of the user interface where an input happened, as passed to the verb
+// script.
+type ClickArea int
+
+const (
+	ClickAreaVerb      ClickArea = 1
+	ClickAreaScene     ClickArea = 2
+	ClickAreaInventory ClickArea = 3
+	ClickAreaKey       ClickArea = 4
+	ClickAreaSentence  ClickArea = 5
+)
+
+const (
+	// ClickLeft and ClickRight are the codes of the mouse buttons passed to the verb script.
+	ClickLeft  = 1
+	ClickRight = 2
+)
+
+// TalkStopKey is the key that skips the current part of the message spoken by an actor.
+const TalkStopKey = '.'
+
+// sentenceLineHeight is the height of the row below the room where the sentence is shown.
+const sentenceLineHeight = 8
+
+// handleInput applies an input event to the engine state. Clicks and keys are passed to the verb
+// script set in VarVerbScript, as long as the userput is enabled.
+func (e *Engine) handleInput(ev InputEvent) error {
+	switch ev.Kind {
+	case InputMouseMove:
+		e.moveMouse(ev.X, ev.Y)
+	case InputLeftClick, InputRightClick:
+		e.moveMouse(ev.X, ev.Y)
+		code := ClickLeft
+		if ev.Kind == InputRightClick {
+			code = ClickRight
+		}
+		return e.click(code)
+	case InputKeyPress:
+		return e.PressKey(ev.Key)
+	}
+	return nil
+}
+
+// moveMouse sets the position of the mouse on screen and in the room.
+func (e *Engine) moveMouse(x, y int) {
+	x = min(max(x, 0), ScreenWidth-1)
+	y = min(max(y, 0), ScreenHeight-1)
+	e.words[VarMouseX] = x
+	e.words[VarMouseY] = y
+	e.words[VarVirtMouseX] = x + e.ScreenLeft()
+	e.words[VarVirtMouseY] = y - e.screenTop
+}
+
+// click runs the verb script for a click with the given button at the mouse position. A click on
+// the row below the room selects the sentence, a click on a verb selects the verb, and a click on
+// the room selects the scene.
+func (e *Engine) click(code int) error {
+	if e.props[PropUIUserputEnabled] <= 0 {
+		return nil
+	}
+	x, y := e.words[VarMouseX], e.words[VarMouseY]
+	if y >= e.screenBottom && y < e.screenBottom+sentenceLineHeight {
+		return e.runInputScript(ClickAreaSentence, 0, code)
+	}
+	if v := e.VerbAt(x, y); v != nil {
+		return e.runInputScript(ClickAreaVerb, int(v.ID), code)
+	}
+	if y >= e.screenTop && y < e.screenBottom {
+		// As in the original interpreters, no object is passed for a scene click. The verb script
+		// finds it by itself from the virtual mouse position.
+		return e.runInputScript(ClickAreaScene, 0, code)
+	}
+	return e.runInputScript(ClickAreaVerb, 0, code)
+}
+
+// PressKey notifies the engine that the given key was pressed. If it matches the key set in
+// VarCutsceneExitKey, the current cutscene is aborted, and TalkStopKey skips the current message.
+// Otherwise, the verb script is run for the verb bound to the key, or for the key itself if there is
+// none.
+func (e *Engine) PressKey(key int) error {
+	if key != 0 && key == e.words[VarCutsceneExitKey] {
+		e.AbortCutscene()
+		return nil
+	}
+	if key == TalkStopKey {
+		e.SkipMessage()
+		return nil
+	}
+	if e.props[PropUIUserputEnabled] <= 0 {
+		return nil
+	}
+	if v := e.VerbForKey(key); v != nil {
+		return e.runInputScript(ClickAreaVerb, int(v.ID), 0)
+	}
+	return e.runInputScript(ClickAreaKey, key, 0)
+}
+
+// runInputScript starts the verb script with the area, the value and the code of the input.
+func (e *Engine) runInputScript(area ClickArea, value, code int) error {
+	script := e.words[VarVerbScript]
+	if script == 0 {
+		return nil
+	}
+	return e.StartScript(ScriptID(script), []int{int(area), value, code}, false)
+}
@@ -0,0 +1,109 @@
+package vm_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	rm := scriptResources{
+		2: {
+			0x9A, 0x64, 0x00, 0x00, 0x40, // 0000: VAR_100 = L0
+			0x9A, 0x65, 0x00, 0x01, 0x40, // 0005: VAR_101 = L1
+			0x9A, 0x66, 0x00, 0x02, 0x40, // 000A: VAR_102 = L2
+			0xA0, // 000F: StopObjectCode
+		},
+	}
+	input := func(eng *vm.Engine, fe *vm.Headless, ev vm.InputEvent) []int {
+		for i := uint16(100); i <= 102; i++ {
+			eng.WriteWord(i, -1)
+		}
+		fe.PushInput(ev)
+		require.NoError(t, eng.RunFrame())
+		return []int{eng.ReadWord(100), eng.ReadWord(101), eng.ReadWord(102)}
+	}
+
+	fe := vm.NewHeadless(new(vm.FakeClock))
+	eng := vm.NewEngine(rm, fe)
+	eng.WriteWord(vm.VarVerbScript, 2)
+	v := eng.NewVerb(7)
+	v.Name, v.X, v.Y, v.Width, v.Height, v.Mode, v.Key = "Open", 10, 160, 30, 8, vm.VerbModeOn, 'o'
+
+	t.Run("Userput disabled", func(t *testing.T) {
+		args := input(eng, fe, vm.InputEvent{Kind: vm.InputLeftClick, X: 100, Y: 50})
+		assert.Equal(t, []int{-1, -1, -1}, args)
+		assert.Equal(t, 100, eng.ReadWord(vm.VarMouseX))
+		assert.Equal(t, 0, eng.ReadWord(vm.VarUserput))
+	})
+
+	eng.SetProperty(vm.PropUIUserputEnabled, 1)
+	for _, test := range []struct {
+		name  string
+		event vm.InputEvent
+		args  []int
+	}{
+		{"Scene", vm.InputEvent{Kind: vm.InputLeftClick, X: 100, Y: 50}, []int{2, 0, 1}},
+		{"Sentence", vm.InputEvent{Kind: vm.InputRightClick, X: 100, Y: 146}, []int{5, 0, 2}},
+		{"Below sentence", vm.InputEvent{Kind: vm.InputLeftClick, X: 200, Y: 152}, []int{1, 0, 1}},
+		{"Verb", vm.InputEvent{Kind: vm.InputLeftClick, X: 20, Y: 162}, []int{1, 7, 1}},
+		{"No verb", vm.InputEvent{Kind: vm.InputLeftClick, X: 200, Y: 180}, []int{1, 0, 1}},
+		{"Verb key", vm.InputEvent{Kind: vm.InputKeyPress, Key: 'o'}, []int{1, 7, 0}},
+		{"Key", vm.InputEvent{Kind: vm.InputKeyPress, Key: 'x'}, []int{4, 'x', 0}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.args, input(eng, fe, test.event))
+			assert.Equal(t, 1, eng.ReadWord(vm.VarUserput))
+		})
+	}
+
+	t.Run("Cutscene exit key", func(t *testing.T) {
+		eng.WriteWord(vm.VarCutsceneExitKey, 27)
+		args := input(eng, fe, vm.InputEvent{Kind: vm.InputKeyPress, Key: 27})
+		assert.Equal(t, []int{-1, -1, -1}, args)
+	})
+}
+
+func TestReadInputScript(t *testing.T) {
+	events, err := vm.ReadInputScript(strings.NewReader(`
+		# Open the door.
+		12 left 160 100
+		10 move 160 100
+		12 key o
+		20 right 40 170
+		30 key 27
+	`))
+	require.NoError(t, err)
+	assert.Equal(t, []vm.ScriptedInput{
+		{Tick: 10, Event: vm.InputEvent{Kind: vm.InputMouseMove, X: 160, Y: 100}},
+		{Tick: 12, Event: vm.InputEvent{Kind: vm.InputLeftClick, X: 160, Y: 100}},
+		{Tick: 12, Event: vm.InputEvent{Kind: vm.InputKeyPress, Key: 'o'}},
+		{Tick: 20, Event: vm.InputEvent{Kind: vm.InputRightClick, X: 40, Y: 170}},
+		{Tick: 30, Event: vm.InputEvent{Kind: vm.InputKeyPress, Key: 27}},
+	}, events)
+
+	for _, script := range []string{"left 1 2", "0 move 1 2", "1 jump", "1 move 1", "1 key", "1 left a 2"} {
+		_, err := vm.ReadInputScript(strings.NewReader(script))
+		assert.ErrorContains(t, err, "line 1", script)
+	}
+
+	fe := vm.NewHeadless(new(vm.FakeClock))
+	fe.PushInput(vm.InputEvent{Kind: vm.InputKeyPress, Key: 'a'})
+	scripted := vm.NewScripted(fe, events)
+	for tick := 1; tick <= 30; tick++ {
+		input := scripted.PollInput()
+		switch tick {
+		case 1:
+			assert.Equal(t, []vm.InputEvent{{Kind: vm.InputKeyPress, Key: 'a'}}, input)
+		case 10, 20, 30:
+			assert.Len(t, input, 1)
+		case 12:
+			assert.Len(t, input, 2)
+		default:
+			assert.Empty(t, input)
+		}
+	}
+}
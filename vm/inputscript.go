@@ -0,0 +1,120 @@
+package vm
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ScriptedInput is an input event to be received at a given tick, the number of the frame that
+// polls it starting at 1.
+type ScriptedInput struct {
+	Tick  int
+	Event InputEvent
+}
+
+// ReadInputScript reads the input events from a text file. Each line has a tick followed by the
+// event, as in:
+//
+//	# Comments and empty lines are ignored.
+//	10 move 160 100
+//	12 left 160 100
+//	20 right 40 170
+//	30 key a
+//	31 key 27
+//
+// Keys are given as a single character or as their numeric code. The events are returned sorted
+// by tick, keeping the order of the events of the same tick.
+func ReadInputScript(r io.Reader) ([]ScriptedInput, error) {
+	var events []ScriptedInput
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		ev, err := parseScriptedInput(fields)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input script: line %d: %w", line, err)
+		}
+		events = append(events, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(events, func(a, b ScriptedInput) int { return a.Tick - b.Tick })
+	return events, nil
+}
+
+func parseScriptedInput(fields []string) (ScriptedInput, error) {
+	var ev ScriptedInput
+	if len(fields) < 2 {
+		return ev, fmt.Errorf("missing event")
+	}
+	tick, err := strconv.Atoi(fields[0])
+	if err != nil || tick < 1 {
+		return ev, fmt.Errorf("invalid tick %q", fields[0])
+	}
+	ev.Tick = tick
+
+	args := fields[2:]
+	switch fields[1] {
+	case "move":
+		ev.Event.Kind = InputMouseMove
+	case "left":
+		ev.Event.Kind = InputLeftClick
+	case "right":
+		ev.Event.Kind = InputRightClick
+	case "key":
+		ev.Event.Kind = InputKeyPress
+		if len(args) != 1 {
+			return ev, fmt.Errorf("key expects 1 argument, got %d", len(args))
+		}
+		if len(args[0]) == 1 {
+			ev.Event.Key = int(args[0][0])
+		} else if ev.Event.Key, err = strconv.Atoi(args[0]); err != nil {
+			return ev, fmt.Errorf("invalid key %q", args[0])
+		}
+		return ev, nil
+	default:
+		return ev, fmt.Errorf("unknown event %q", fields[1])
+	}
+	if len(args) != 2 {
+		return ev, fmt.Errorf("%s expects 2 arguments, got %d", fields[1], len(args))
+	}
+	if ev.Event.X, err = strconv.Atoi(args[0]); err != nil {
+		return ev, fmt.Errorf("invalid position %q", args[0])
+	}
+	if ev.Event.Y, err = strconv.Atoi(args[1]); err != nil {
+		return ev, fmt.Errorf("invalid position %q", args[1])
+	}
+	return ev, nil
+}
+
+// Scripted is a frontend that adds the events of an input script to the input of another frontend
+// when their tick comes.
+type Scripted struct {
+	Frontend
+
+	events []ScriptedInput
+	tick   int
+}
+
+// NewScripted returns a frontend that feeds the given events, sorted by tick, on top of fe.
+func NewScripted(fe Frontend, events []ScriptedInput) *Scripted {
+	return &Scripted{Frontend: fe, events: events}
+}
+
+// PollInput implements the Frontend interface.
+func (s *Scripted) PollInput() []InputEvent {
+	s.tick++
+	input := s.Frontend.PollInput()
+	for len(s.events) > 0 && s.events[0].Tick <= s.tick {
+		input = append(input, s.events[0].Event)
+		s.events = s.events[1:]
+	}
+	return input
+}
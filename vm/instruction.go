@@ -56,5 +56,14 @@ func (p Params) Display(st *SymbolTable) string {
 	return str.String()
 }
 
+// Evaluate evaluates the parameters.
+func (p Params) Evaluate(ctx ExecutionContext) []int {
+	values := make([]int, len(p))
+	for i, param := range p {
+		values[i] = param.Evaluate(ctx)
+	}
+	return values
+}
+
 // Instruction is an instruction of the bytecode scripting language.
 type Instruction interface{}
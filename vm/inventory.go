@@ -0,0 +1,162 @@
+package vm
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MaxInventory is the maximum number of objects in the inventory of all the actors.
+const MaxInventory = 80
+
+// ObjectOwnerRoom is the owner of the objects that belong to their room.
+const ObjectOwnerRoom ObjectOwner = 0x0F
+
+// inventoryItem is an object owned by an actor. The object code is kept, if known, so the object
+// can be used after leaving the room it came from.
+type inventoryItem struct {
+	id   ObjectID
+	code *RoomObject
+
+	// room is the room resource the code was taken from.
+	room RoomID
+}
+
+// SetObjectOwner changes the owner of the object. The object enters the inventory when it is owned
+// by an actor, and leaves it when it goes back to the room. The inventory script set in
+// VarInventoryScript, if any, is run afterwards.
+func (e *Engine) SetObjectOwner(id ObjectID, owner ObjectOwner) error {
+	if owner == 0 || owner == ObjectOwnerRoom {
+		e.removeFromInventory(id)
+	} else if err := e.addToInventory(id); err != nil {
+		return err
+	}
+	e.objectRef(id).Owner = owner
+	return e.runInventoryScript(0)
+}
+
+// PickUpObject gives the object to the ego actor, making it untouchable in the room. The inventory
+// script set in VarInventoryScript, if any, is run afterwards.
+func (e *Engine) PickUpObject(id ObjectID) error {
+	if err := e.addToInventory(id); err != nil {
+		return err
+	}
+	obj := e.objectRef(id)
+	obj.Owner = ObjectOwner(e.words[VarEgo])
+	obj.Class = obj.Class.With(ObjectClassUntouchable, true)
+	obj.State = 1
+	return e.runInventoryScript(1)
+}
+
+// InventoryCount returns the number of objects owned by the given owner.
+func (e *Engine) InventoryCount(owner ObjectOwner) int {
+	var n int
+	for _, item := range e.inventory {
+		if e.Object(item.id).Owner == owner {
+			n++
+		}
+	}
+	return n
+}
+
+// FindInventory returns the n-th object owned by the given owner, counting from 1 in the order
+// they entered the inventory, or 0 if there is none.
+func (e *Engine) FindInventory(owner ObjectOwner, n int) ObjectID {
+	for _, item := range e.inventory {
+		if e.Object(item.id).Owner == owner {
+			if n--; n == 0 {
+				return item.id
+			}
+		}
+	}
+	return 0
+}
+
+// inventoryCode returns the object code of the given object in the inventory, or nil if it is not
+// there. The code of the objects that did not enter the inventory from the current room, like the
+// ones owned since the start of the game, is loaded from their room the first time it is needed.
+func (e *Engine) inventoryCode(id ObjectID) (*RoomObject, error) {
+	for i := range e.inventory {
+		item := &e.inventory[i]
+		if item.id != id {
+			continue
+		}
+		if item.code == nil {
+			if err := e.loadInventoryCode(item); err != nil {
+				return nil, err
+			}
+		}
+		return item.code, nil
+	}
+	return nil, nil
+}
+
+func (e *Engine) loadInventoryCode(item *inventoryItem) error {
+	room, err := e.rm.GetObjectRoom(item.id)
+	if err != nil {
+		return fmt.Errorf("cannot load code of object %d: %w", item.id, err)
+	}
+	r, err := e.rm.GetRoom(room, true)
+	if err != nil {
+		return fmt.Errorf("cannot load code of object %d: %w", item.id, err)
+	}
+	obj := r.Object(item.id)
+	if obj == nil {
+		return fmt.Errorf("cannot load code of object %d: not found in room %d", item.id, room)
+	}
+	code := *obj
+	item.code, item.room = &code, room
+	return nil
+}
+
+// seedInventory puts in the inventory the objects that are initially owned by actors.
+func (e *Engine) seedInventory() {
+	var ids []ObjectID
+	for id, obj := range e.objects {
+		if obj.Owner != 0 && obj.Owner != ObjectOwnerRoom {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		if len(e.inventory) < MaxInventory {
+			e.inventory = append(e.inventory, inventoryItem{id: id})
+		}
+	}
+}
+
+func (e *Engine) addToInventory(id ObjectID) error {
+	for _, item := range e.inventory {
+		if item.id == id {
+			return nil
+		}
+	}
+	if len(e.inventory) >= MaxInventory {
+		return fmt.Errorf("cannot add object %d to inventory: inventory is full", id)
+	}
+	item := inventoryItem{id: id}
+	if e.room != nil {
+		if obj := e.room.Object(id); obj != nil {
+			code := *obj
+			item.code = &code
+			item.room = e.RoomResource(e.roomID)
+		}
+	}
+	e.inventory = append(e.inventory, item)
+	return nil
+}
+
+func (e *Engine) removeFromInventory(id ObjectID) {
+	for i, item := range e.inventory {
+		if item.id == id {
+			e.inventory = append(e.inventory[:i], e.inventory[i+1:]...)
+			return
+		}
+	}
+}
+
+func (e *Engine) runInventoryScript(arg int) error {
+	if script := e.words[VarInventoryScript]; script != 0 {
+		return e.StartScript(ScriptID(script), []int{arg}, false)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventory(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x1A, 0x22, 0x00, 0x02, 0x00, // 0000: VAR_INVENTORY_SCRIPT = 2
+			0x29, 0x64, 0x00, 0x01, // 0005: SetObjectOwner 100 1
+			0x29, 0x65, 0x00, 0x01, // 0009: SetObjectOwner 101 1
+			0x29, 0x66, 0x00, 0x02, // 000D: SetObjectOwner 102 2
+			0x31, 0x64, 0x00, 0x01, // 0011: VAR_100 = GetInventoryCount 1
+			0x3D, 0x65, 0x00, 0x01, 0x02, // 0015: VAR_101 = FindInventory 1 2
+			0x29, 0x64, 0x00, 0x0F, // 001A: SetObjectOwner 100 15
+			0x3D, 0x66, 0x00, 0x01, 0x01, // 001E: VAR_102 = FindInventory 1 1
+			0x3D, 0x67, 0x00, 0x01, 0x02, // 0023: VAR_103 = FindInventory 1 2
+			0xA0, // 0028: StopObjectCode
+		},
+		2: {
+			0x1A, 0x68, 0x00, 0x07, 0x00, // 0000: VAR_104 = 7
+			0xA0, // 0005: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	assert.Equal(t, 2, eng.ReadWord(100))
+	assert.Equal(t, 101, eng.ReadWord(101))
+	assert.Equal(t, 101, eng.ReadWord(102))
+	assert.Equal(t, 0, eng.ReadWord(103))
+	assert.Equal(t, 7, eng.ReadWord(104))
+	assert.Equal(t, 1, eng.InventoryCount(2))
+}
+
+func TestInventoryOwnedAtStart(t *testing.T) {
+	rm := gameResources{
+		scriptResources: scriptResources{
+			1: {
+				0x37, 0x64, 0x00, 0x05, 0x01, 0x2A, 0x00, 0xFF, // 0000: StartObject 100 5 [42]
+				0xA0, // 0008: StopObjectCode
+			},
+		},
+		rooms: map[vm.RoomID]vm.Room{
+			10: {
+				Objects: []vm.RoomObject{{
+					ID:         100,
+					Name:       "key",
+					Verbs:      []vm.ObjectVerb{{Verb: 5, Address: 0}},
+					CodeOffset: 20,
+					Script: vm.Script{Bytecode: []byte{
+						0x9A, 0x64, 0x00, 0x00, 0x40, // 0000: VAR_100 = L0
+						0xA0, // 0005: StopObjectCode
+					}},
+				}},
+			},
+		},
+		objects: map[vm.ObjectID]vm.IndexedObject{100: {ID: 100, Owner: 1}},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	assert.Equal(t, vm.ObjectID(100), eng.FindInventory(1, 1))
+	entry, err := eng.VerbEntrypoint(100, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 20, entry)
+
+	require.NoError(t, eng.StartScript(1, nil, false))
+	assert.Equal(t, 42, eng.ReadWord(100))
+	assert.Equal(t, "key", eng.ObjectName(100))
+}
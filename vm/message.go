@@ -0,0 +1,126 @@
+package vm
+
+// DefaultTalkDelay is the number of jiffies a message is shown on screen, besides the ones added for
+// each of its characters as set in VarCharInc.
+const DefaultTalkDelay = 60
+
+const (
+	// MessageNone is the value of VarHaveMsg when no message is being spoken.
+	MessageNone = 0
+
+	// MessageDone is the value of VarHaveMsg when the whole message is shown and it is waiting for
+	// its talk delay to expire.
+	MessageDone = 1
+
+	// MessagePending is the value of VarHaveMsg when the message has parts not shown yet.
+	MessagePending = 0xFF
+)
+
+// actorTalkPosY is the vertical offset of the messages spoken by actors respect their position.
+const actorTalkPosY = -80
+
+// message is the message spoken by an actor.
+type message struct {
+	actor int
+	style TextStyle
+
+	// rest is the text after a wait code, shown once the current part is done.
+	rest []byte
+}
+
+// TalkDelay returns the number of jiffies the current part of the message will still be shown.
+func (e *Engine) TalkDelay() int {
+	return e.talkDelay
+}
+
+// SkipMessage ends the current part of the message spoken by an actor, so the next one is shown or
+// the actor stops talking in the next frame.
+func (e *Engine) SkipMessage() {
+	e.talkDelay = 0
+}
+
+// StopTalk removes the message spoken by an actor from the screen and stops the talk animation.
+func (e *Engine) StopTalk() {
+	e.haveMsg = MessageNone
+	e.talkDelay = 0
+	if a := e.Actor(ActorID(e.words[VarTalkActor])); a != nil {
+		a.Animate(a.TalkStopFrame)
+	}
+	e.words[VarTalkActor] = 0
+	e.keepText = false
+	e.message = message{}
+	e.ClearTexts(TextSlotTalk)
+}
+
+// talk starts the message spoken by the given actor. The message is shown in the talk color of
+// the actor, above its head if the style is overhead, and the actor plays its talk animation.
+func (e *Engine) talk(actor int, msg []byte) {
+	if !e.keepText {
+		e.StopTalk()
+	}
+	e.words[VarTalkActor] = 0xFF
+
+	style := e.textStyles[TextSlotTalk]
+	if a := e.Actor(ActorID(actor)); a != nil {
+		e.words[VarTalkActor] = actor
+		style.Color = a.TalkColor
+		if a.Room != 0 && a.Room == e.roomID {
+			a.Animate(a.TalkStartFrame)
+			if style.Overhead {
+				style.X = a.X - e.ScreenLeft()
+				style.Y = a.Y - a.Elevation
+				s := a.ScaleY * actorTalkPosY / 0xFF
+				style.Y += (actorTalkPosY-s)/2 + s
+				style.Y = min(style.Y, ScreenHeight-40)
+			}
+		}
+	}
+	style.Y = max(style.Y, 1)
+	style.X = min(max(style.X, 80), ScreenWidth-80)
+
+	e.keepText = false
+	e.message = message{actor: actor, style: style, rest: msg}
+	e.words[VarHaveMsg] = MessagePending
+	e.showMessage()
+}
+
+// showMessage shows the part of the message spoken by an actor up to the next wait code, and sets
+// the time it stays on screen according to its length.
+func (e *Engine) showMessage() {
+	lines, rest, keep := splitMessage(e.message.rest)
+	e.message.rest = rest
+	e.keepText = keep
+
+	e.talkDelay = DefaultTalkDelay
+	for _, line := range lines {
+		e.talkDelay += len(line) * e.words[VarCharInc]
+	}
+	style := e.message.style
+	e.drawLines(TextSlotTalk, style, e.wrapLines(style, lines))
+
+	switch {
+	case keep:
+		e.haveMsg = MessageNone
+	case rest != nil:
+		e.haveMsg = MessagePending
+	default:
+		e.haveMsg = MessageDone
+	}
+}
+
+// updateMessage advances the message spoken by an actor once its talk delay expires, showing the
+// next part of the message or stopping the talk if there is none.
+func (e *Engine) updateMessage(delta int) {
+	e.talkDelay = max(e.talkDelay-delta, 0)
+	if e.haveMsg == MessageNone || e.talkDelay > 0 {
+		return
+	}
+	if e.haveMsg != MessagePending {
+		e.StopTalk()
+		return
+	}
+	if !e.keepText {
+		e.ClearTexts(TextSlotTalk)
+	}
+	e.showMessage()
+}
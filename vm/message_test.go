@@ -0,0 +1,74 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageTiming(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x1A, 0x25, 0x00, 0x02, 0x00, // 0000: VAR_CHARINC = 2
+			0x14, 0x01, 0x0F, 'H', 'i', 0xFF, 0x03, 'B', 'y', 'e', 0x00, // 0005: Print 1 text="Hi\xFF\x03Bye"
+			0xAE, 0x02, // 0010: WaitForMessage
+			0x1A, 0x64, 0x00, 0x01, 0x00, // 0012: VAR_100 = 1
+			0xA0, // 0017: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	texts := func() []string {
+		var s []string
+		for _, t := range eng.Texts() {
+			s = append(s, t.String)
+		}
+		return s
+	}
+	assert.Equal(t, []string{"Hi"}, texts())
+	assert.Equal(t, vm.MessagePending, eng.ReadWord(vm.VarHaveMsg))
+	assert.Equal(t, 1, eng.ReadWord(vm.VarTalkActor))
+	assert.Equal(t, vm.DefaultTalkDelay+4, eng.TalkDelay())
+
+	for i := 0; i < 15; i++ {
+		require.NoError(t, eng.RunFrame())
+	}
+	assert.Equal(t, []string{"Hi"}, texts())
+
+	require.NoError(t, eng.RunFrame())
+	assert.Equal(t, []string{"Bye"}, texts())
+	assert.Equal(t, vm.MessageDone, eng.ReadWord(vm.VarHaveMsg))
+	assert.Equal(t, vm.DefaultTalkDelay+6, eng.TalkDelay())
+	assert.Equal(t, 0, eng.ReadWord(100))
+
+	eng.SkipMessage()
+	require.NoError(t, eng.RunFrame())
+	assert.Empty(t, texts())
+	assert.Equal(t, vm.MessageNone, eng.ReadWord(vm.VarHaveMsg))
+	assert.Equal(t, 0, eng.ReadWord(vm.VarTalkActor))
+	assert.Equal(t, 1, eng.ReadWord(100))
+}
+
+func TestSkipMessageKey(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x14, 0x01, 0x0F, 'H', 'i', 0x00, // 0000: Print 1 text="Hi"
+			0xA0, // 0006: StopObjectCode
+		},
+	}
+
+	fe := vm.NewHeadless(new(vm.FakeClock))
+	eng := vm.NewEngine(rm, fe)
+	require.NoError(t, eng.StartScript(1, nil, false))
+	require.Len(t, eng.Texts(), 1)
+
+	fe.PushInput(vm.InputEvent{Kind: vm.InputKeyPress, Key: vm.TalkStopKey})
+	require.NoError(t, eng.RunFrame())
+	assert.Empty(t, eng.Texts())
+	assert.Equal(t, vm.MessageNone, eng.ReadWord(vm.VarHaveMsg))
+	assert.Equal(t, 0, eng.ReadWord(vm.VarTalkActor))
+}
@@ -1,6 +1,9 @@
 package vm
 
-import "fmt"
+import (
+	"fmt"
+	"image"
+)
 
 // ObjectID is the ID of an object.
 type ObjectID int
@@ -23,6 +26,22 @@ func (class ObjectClass) String() string {
 	return fmt.Sprintf("$%06x", uint32(class))
 }
 
+// Has returns true if the class bitmask includes the class with the given number.
+func (class ObjectClass) Has(n ObjectClass) bool {
+	return n > 0 && n <= 32 && class&(1<<(n-1)) != 0
+}
+
+// With returns the class bitmask with the class with the given number set or cleared.
+func (class ObjectClass) With(n ObjectClass, set bool) ObjectClass {
+	if n == 0 || n > 32 {
+		return class
+	}
+	if set {
+		return class | 1<<(n-1)
+	}
+	return class &^ (1 << (n - 1))
+}
+
 type ObjectOwner byte
 
 func (owner ObjectOwner) String() string {
@@ -34,3 +53,213 @@ type ObjectState byte
 func (state ObjectState) String() string {
 	return fmt.Sprintf("$%02x", byte(state))
 }
+
+// RoomObject is an object placed in a room, as described by its object code.
+type RoomObject struct {
+	ID          ObjectID
+	X           int
+	Y           int
+	Width       int
+	Height      int
+	Parent      uint8
+	ParentState ObjectState
+	WalkX       int
+	WalkY       int
+	ActorDir    uint8
+	Name        string
+
+	// Verbs are the script entry points for each verb the object reacts to.
+	Verbs []ObjectVerb
+
+	// Script is the code of all the verb entry points.
+	Script Script
+
+	// CodeOffset is the offset of the script within the object code resource. Verb entry points
+	// are reported to the scripts relative to the object code, so they are never 0.
+	CodeOffset uint16
+
+	// Image is the picture of the object drawn over the room background, or nil if it has none.
+	// The pixels of TransparentColor are not drawn.
+	Image *image.Paletted
+}
+
+// ObjectVerb is the entry point of an object script for a given verb.
+type ObjectVerb struct {
+	// Verb is the verb ID, or ObjectVerbDefault for the entry that matches any verb.
+	Verb uint8
+
+	// Address is the address of the entry point in the object script.
+	Address uint16
+}
+
+// ObjectVerbDefault is the verb of the object entry point that matches any verb.
+const ObjectVerbDefault = 0xFF
+
+// verb returns the entry point of the object for the given verb, or nil if there is none.
+func (obj *RoomObject) verb(verb VerbID) *ObjectVerb {
+	for i := range obj.Verbs {
+		if v := &obj.Verbs[i]; VerbID(v.Verb) == verb || v.Verb == ObjectVerbDefault {
+			return v
+		}
+	}
+	return nil
+}
+
+// Object is the runtime state of an object.
+type Object struct {
+	ID    ObjectID
+	Class ObjectClass
+	Owner ObjectOwner
+	State ObjectState
+
+	// Name is the name set by the scripts, overriding the one from the object code if not empty.
+	Name string
+}
+
+// Object returns the runtime state of the object with the given ID. The state of an object that
+// was never changed is empty.
+func (e *Engine) Object(id ObjectID) Object {
+	if obj, ok := e.objects[id]; ok {
+		return *obj
+	}
+	return Object{ID: id}
+}
+
+// objectRef returns the runtime state of the object with the given ID to be changed, creating it if
+// needed.
+func (e *Engine) objectRef(id ObjectID) *Object {
+	obj, ok := e.objects[id]
+	if !ok {
+		obj = &Object{ID: id}
+		e.objects[id] = obj
+	}
+	return obj
+}
+
+// SetObjectState sets the state of the given object.
+func (e *Engine) SetObjectState(id ObjectID, state ObjectState) {
+	e.objectRef(id).State = state
+}
+
+// ObjectName returns the name of the object, or an empty string if it is unknown.
+func (e *Engine) ObjectName(id ObjectID) string {
+	if id > 0 && int(id) < MaxActors {
+		return e.actors[id].Name
+	}
+	if obj, ok := e.objects[id]; ok && obj.Name != "" {
+		return obj.Name
+	}
+	if e.room != nil {
+		if obj := e.room.Object(id); obj != nil {
+			return obj.Name
+		}
+	}
+	// The name is only cosmetic, so an object whose code cannot be loaded has no name.
+	if code, _ := e.inventoryCode(id); code != nil {
+		return code.Name
+	}
+	return ""
+}
+
+// SetObjectName sets the name of the object.
+func (e *Engine) SetObjectName(id ObjectID, name string) {
+	if id > 0 && int(id) < MaxActors {
+		e.actors[id].Name = name
+		return
+	}
+	e.objectRef(id).Name = name
+}
+
+// SetClass sets or clears the class with the given number in the given object or actor. A class
+// number of 0 clears all the classes.
+func (e *Engine) SetClass(id ObjectID, class ObjectClass, set bool) {
+	obj := e.objectRef(id)
+	if class == ObjectClassNone {
+		obj.Class = 0
+	} else {
+		obj.Class = obj.Class.With(class, set)
+	}
+	if a := e.Actor(ActorID(id)); a != nil && (class == ObjectClassNone || class == ObjectClassIgnoreBoxes) {
+		a.IgnoreBoxes = set && class == ObjectClassIgnoreBoxes
+	}
+}
+
+// HasClass returns true if the given object or actor has the class with the given number.
+func (e *Engine) HasClass(id ObjectID, class ObjectClass) bool {
+	return e.Object(id).Class.Has(class)
+}
+
+// FindObject returns the ID of the topmost touchable object of the current room at the given
+// position, or 0 if there is none.
+func (e *Engine) FindObject(x, y int) ObjectID {
+	if e.room == nil {
+		return 0
+	}
+	for i := len(e.room.Objects) - 1; i >= 0; i-- {
+		ro := &e.room.Objects[i]
+		if e.Object(ro.ID).Class.Has(ObjectClassUntouchable) || !e.objectVisible(i) {
+			continue
+		}
+		if x >= ro.X && x < ro.X+ro.Width && y >= ro.Y && y < ro.Y+ro.Height {
+			return ro.ID
+		}
+	}
+	return 0
+}
+
+// DrawObject shows the given object of the current room by setting its state to 1. Unless x is
+// 0xFF, the object is moved first to the given position, in 8 pixel units. The objects covering the
+// same area are hidden, since they are the alternative images of the same room element.
+func (e *Engine) DrawObject(id ObjectID, x, y int) {
+	if e.room == nil {
+		return
+	}
+	ro := e.room.Object(id)
+	if ro == nil {
+		return
+	}
+	if x != 0xFF {
+		ro.WalkX += x*8 - ro.X
+		ro.X = x * 8
+		ro.WalkY += y*8 - ro.Y
+		ro.Y = y * 8
+	}
+	for i := range e.room.Objects {
+		other := &e.room.Objects[i]
+		if other.X == ro.X && other.Y == ro.Y && other.Width == ro.Width && other.Height == ro.Height {
+			e.SetObjectState(other.ID, 0)
+		}
+	}
+	e.SetObjectState(id, 1)
+}
+
+// Distance returns the distance between two actors or objects of the current room, or 0xFF if any
+// of them is not there.
+func (e *Engine) Distance(a, b int) int {
+	x1, y1, ok1 := e.ObjectPosition(a)
+	x2, y2, ok2 := e.ObjectPosition(b)
+	if !ok1 || !ok2 {
+		return 0xFF
+	}
+	return max(abs(x1-x2), abs(y1-y2))
+}
+
+// objectVisible returns true if the parents of the i-th object of the room are in the state it
+// requires to be shown.
+func (e *Engine) objectVisible(i int) bool {
+	for n := 0; n < len(e.room.Objects); n++ {
+		ro := &e.room.Objects[i]
+		if ro.Parent == 0 {
+			return true
+		}
+		p := int(ro.Parent) - 1
+		if p < 0 || p >= len(e.room.Objects) {
+			return true
+		}
+		if e.Object(e.room.Objects[p].ID).State != ro.ParentState {
+			return false
+		}
+		i = p
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectState(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x5D, 0x64, 0x00, 0x01, 0x98, 0x00, 0xFF, // 0000: SetClass 100 [152]
+			0x07, 0x64, 0x00, 0x02, // 0007: SetObjectState 100 2
+			0x29, 0x64, 0x00, 0x03, // 000B: SetObjectOwner 100 3
+			0x10, 0x65, 0x00, 0x64, 0x00, // 000F: VAR_101 = GetObjectOwner 100
+			0x1D, 0x64, 0x00, 0x01, 0x98, 0x00, 0xFF, 0x05, 0x00, // 0014: BranchUnlessClass 100 [152] 0022
+			0x1A, 0x66, 0x00, 0x01, 0x00, // 001D: VAR_102 = 1
+			0x0F, 0x64, 0x00, 0x02, 0x05, 0x00, // 0022: BranchUnlessState 100 2 002D
+			0x1A, 0x67, 0x00, 0x01, 0x00, // 0028: VAR_103 = 1
+			0xA0, // 002D: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	obj := eng.Object(100)
+	assert.True(t, obj.Class.Has(vm.ObjectClassUntouchable))
+	assert.Equal(t, vm.ObjectState(2), obj.State)
+	assert.Equal(t, 3, eng.ReadWord(101))
+	assert.Equal(t, 1, eng.ReadWord(102))
+	assert.Equal(t, 1, eng.ReadWord(103))
+}
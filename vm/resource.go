@@ -23,14 +23,29 @@ func (offset ChunkOffset) String() string {
 
 // ResourceManager is a manager for SCUMM resources.
 type ResourceManager interface {
-	// GetRoom returns a room from its ID.
-	GetRoom(id RoomID) (*Room, error)
+	// GetRoom returns a room from its ID. If decode is true, the room scripts are decoded.
+	GetRoom(id RoomID, decode bool) (*Room, error)
 
-	// GetRoomByName returns a room from its name.
-	GetRoomByName(name RoomName) (*Room, error)
+	// GetRoomByName returns a room from its name. If decode is true, the room scripts are decoded.
+	GetRoomByName(name RoomName, decode bool) (*Room, error)
 
 	// GetScript returns a script from its ID. If decode is true, the script bytecode is decoded.
 	GetScript(id ScriptID, decode bool) (*Script, error)
+
+	// GetCharset returns a charset from its ID.
+	GetCharset(id CharsetID) (*Charset, error)
+
+	// GetCostume returns a costume from its ID.
+	GetCostume(id CostumeID) (*Costume, error)
+
+	// GetSound returns a sound from its ID.
+	GetSound(id SoundID) (*Sound, error)
+
+	// GetObjects returns the initial class, owner and state of the objects of the game.
+	GetObjects() map[ObjectID]IndexedObject
+
+	// GetObjectRoom returns the room whose resources contain the code of the given object.
+	GetObjectRoom(id ObjectID) (RoomID, error)
 }
 
 // GetRoomFromRef returns a room from a reference in a string form that can be either a room ID or a
@@ -38,12 +53,12 @@ type ResourceManager interface {
 func GetRoomFromRef(man ResourceManager, ref string) (*Room, error) {
 	id, err := strconv.Atoi(ref)
 	if err == nil {
-		return man.GetRoom(RoomID(id))
+		return man.GetRoom(RoomID(id), false)
 	}
 
 	name, err := ParseRoomName(ref)
 	if err != nil {
 		return nil, err
 	}
-	return man.GetRoomByName(name)
+	return man.GetRoomByName(name, false)
 }
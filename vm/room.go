@@ -2,6 +2,8 @@ package vm
 
 import (
 	"fmt"
+	"image"
+	"image/color"
 	"strings"
 )
 
@@ -30,6 +32,12 @@ func (name RoomName) String() string {
 	return strings.Trim(string(name[:]), "\x00")
 }
 
+// IsPseudo returns true if the room ID is a pseudo room that must be mapped to an actual room
+// resource before loading it.
+func (id RoomID) IsPseudo() bool {
+	return id >= 0x80
+}
+
 // Room is a room in the game.
 type Room struct {
 	ID                   RoomID
@@ -39,4 +47,58 @@ type Room struct {
 	NumberOfObjects      uint16
 	NumberOfLocalScripts uint8
 	LocalScripts         []Script
+	Objects              []RoomObject
+	Boxes                []Box
+	ExitScript           Script
+	EntryScript          Script
+
+	// Palette is the palette the room is drawn with.
+	Palette color.Palette
+
+	// Background is the image of the room. ZPlanes are the masks of the room elements that hide the
+	// actors standing behind them. An actor is masked by the z-plane selected by the mask of its
+	// walk box, counting from 1.
+	Background *image.Paletted
+	ZPlanes    []*image.Alpha
+}
+
+// LocalScript returns the local script of the room with the given ID, or nil if there is none.
+func (r *Room) LocalScript(id ScriptID) *Script {
+	for i := range r.LocalScripts {
+		if r.LocalScripts[i].ID == id {
+			return &r.LocalScripts[i]
+		}
+	}
+	return nil
+}
+
+// Object returns the object of the room with the given ID, or nil if there is none.
+func (r *Room) Object(id ObjectID) *RoomObject {
+	for i := range r.Objects {
+		if r.Objects[i].ID == id {
+			return &r.Objects[i]
+		}
+	}
+	return nil
+}
+
+// Decode decodes the bytecode of all the scripts of the room.
+func (r *Room) Decode(dec InstructionDecoder) error {
+	for i := range r.LocalScripts {
+		if err := r.LocalScripts[i].Decode(dec); err != nil {
+			return fmt.Errorf("error decoding local script %d: %w", r.LocalScripts[i].ID, err)
+		}
+	}
+	for i := range r.Objects {
+		if err := r.Objects[i].Script.Decode(dec); err != nil {
+			return fmt.Errorf("error decoding script of object %d: %w", r.Objects[i].ID, err)
+		}
+	}
+	if err := r.ExitScript.Decode(dec); err != nil {
+		return fmt.Errorf("error decoding exit script: %w", err)
+	}
+	if err := r.EntryScript.Decode(dec); err != nil {
+		return fmt.Errorf("error decoding entry script: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,610 @@
+package vm
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// SaveVersion is the version of the format of the saved games written by Engine.Save. It must be
+// increased whenever the saved state changes.
+const SaveVersion = 1
+
+// saveMagic is the signature at the beginning of the saved games, followed by their version.
+var saveMagic = [4]byte{'S', 'C', 'V', 'M'}
+
+const (
+	// SaveLoadSave and SaveLoadLoad are the operations requested by RoomSaveGame.
+	SaveLoadSave = 1
+	SaveLoadLoad = 2
+)
+
+// SaveGameName returns the name of the file of the store where the game of the given slot is
+// saved.
+func SaveGameName(slot int) string {
+	return fmt.Sprintf("savegame.%03d", slot)
+}
+
+// savedState is the state of the engine as written in a saved game. The rooms and the scripts are
+// not saved, but loaded again from the resources by their IDs.
+type savedState struct {
+	Props map[Property]int
+	Words []int
+	Bits  []byte
+
+	Slots     []savedThread
+	Cutscenes []savedCutscene
+
+	RoomID      RoomID
+	PseudoRooms [0x80]RoomID
+	Boxes       []Box
+	RoomObjects []savedRoomObject
+
+	Actors    [MaxActors]savedActor
+	Objects   []Object
+	Inventory []savedInventoryItem
+	Verbs     [MaxVerbs]Verb
+	Sentences []savedSentence
+	Strings   [MaxStrings][]byte
+	Charset   CharsetID
+
+	TextStyles   [numTextSlots]TextStyle
+	TextDefaults [numTextSlots]TextStyle
+	Texts        []savedText
+	Message      savedMessage
+	KeepText     bool
+	HaveMsg      int
+	TalkDelay    int
+
+	Camera        Camera
+	MovingToActor bool
+	ScreenTop     int
+	ScreenBottom  int
+
+	Sounds []SoundID
+	Music  SoundID
+
+	Jiffies int
+}
+
+type savedThread struct {
+	Slot      int
+	Script    ScriptID
+	Object    ObjectID
+	Inventory bool
+	IP        int
+	Local     []int
+	Status    ThreadStatus
+	Delay     int
+}
+
+type savedCutscene struct {
+	Data    int
+	Cursor  int
+	Userput int
+
+	// Slot is the slot of the thread that registered the override point, or -1 if there is none.
+	Slot   int
+	Target uint16
+}
+
+// savedRoomObject is the position of an object of the current room, which may be moved by the
+// scripts.
+type savedRoomObject struct {
+	ID    ObjectID
+	X     int
+	Y     int
+	WalkX int
+	WalkY int
+}
+
+type savedActor struct {
+	Actor Actor
+	Walk  savedWalk
+	Cost  savedCostume
+}
+
+type savedWalk struct {
+	Dest    Point
+	DestBox int
+	Dir     int
+	From    Point
+	To      Point
+	Box     int
+	XFrac   int
+	YFrac   int
+	DX      int
+	DY      int
+}
+
+type savedCostume struct {
+	ID      CostumeID
+	Facing  int
+	Pending []int
+	Stopped uint16
+	Limbs   [CostumeLimbs]savedLimb
+}
+
+type savedLimb struct {
+	Anim   int
+	Pos    int
+	Start  int
+	End    int
+	NoLoop bool
+	Shown  bool
+}
+
+// savedInventoryItem is an object of the inventory, whose code is loaded again from the room it
+// was taken from, if any.
+type savedInventoryItem struct {
+	ID   ObjectID
+	Room RoomID
+}
+
+type savedSentence struct {
+	Verb VerbID
+	ObjA ObjectID
+	ObjB ObjectID
+}
+
+type savedText struct {
+	Text    Text
+	Charset CharsetID
+}
+
+type savedMessage struct {
+	Actor int
+	Style TextStyle
+	Rest  []byte
+}
+
+// Save writes the state of the engine, including the running threads, to w. The state can be
+// restored later by Load on an engine that uses the same resources.
+func (e *Engine) Save(w io.Writer) error {
+	if err := binary.Write(w, binary.LittleEndian, saveMagic); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint16(SaveVersion)); err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(w).Encode(e.saveState()); err != nil {
+		return fmt.Errorf("could not save game: %w", err)
+	}
+	return nil
+}
+
+// Load restores the state of the engine saved by Save. The rooms and the scripts referred by the
+// saved state are loaded again from the resources, and the sounds being played are started again.
+// On error, the state of the engine is left unchanged, unless the frontend fails to play the sounds.
+func (e *Engine) Load(r io.Reader) error {
+	var header struct {
+		Magic   [4]byte
+		Version uint16
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return fmt.Errorf("could not load game: %w", err)
+	}
+	if header.Magic != saveMagic {
+		return errors.New("could not load game: not a saved game")
+	}
+	if header.Version != SaveVersion {
+		return fmt.Errorf("could not load game: unsupported version %d", header.Version)
+	}
+	var st savedState
+	if err := gob.NewDecoder(r).Decode(&st); err != nil {
+		return fmt.Errorf("could not load game: %w", err)
+	}
+	if err := e.loadState(&st); err != nil {
+		return fmt.Errorf("could not load game: %w", err)
+	}
+	return nil
+}
+
+// SaveLoadGame requests the game to be saved into or loaded from the given slot of the store. The
+// operation takes place at the end of the frame, once all the threads yielded.
+func (e *Engine) SaveLoadGame(op, slot int) {
+	e.saveLoad = op
+	e.saveLoadSlot = slot
+}
+
+// SetStore sets the store where the games are saved.
+func (e *Engine) SetStore(s Store) {
+	e.store = s
+}
+
+// runSaveLoad saves or loads the game as requested by SaveLoadGame, if any. A failure does not stop
+// the engine, since a failed load leaves the state untouched. It is kept to be reported by
+// SaveLoadError instead.
+func (e *Engine) runSaveLoad() {
+	if e.saveLoad != 0 {
+		e.saveLoadErr = e.saveOrLoad(e.saveLoad, e.saveLoadSlot)
+		e.saveLoad = 0
+	}
+}
+
+func (e *Engine) saveOrLoad(op, slot int) error {
+	name := SaveGameName(slot)
+	switch op {
+	case SaveLoadSave:
+		f, err := e.store.Create(name)
+		if err != nil {
+			return fmt.Errorf("could not save game to slot %d: %w", slot, err)
+		}
+		if err := e.Save(f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	case SaveLoadLoad:
+		f, err := e.store.Open(name)
+		if err != nil {
+			return fmt.Errorf("could not load game from slot %d: %w", slot, err)
+		}
+		defer f.Close()
+		return e.Load(f)
+	}
+	return nil
+}
+
+// SaveLoadError returns the error of the last save or load requested by SaveLoadGame, or nil if it
+// succeeded.
+func (e *Engine) SaveLoadError() error {
+	return e.saveLoadErr
+}
+
+func (e *Engine) saveState() *savedState {
+	st := &savedState{
+		Props:         e.props,
+		Words:         e.words,
+		Bits:          e.bits,
+		RoomID:        e.roomID,
+		PseudoRooms:   e.pseudoRooms,
+		Verbs:         e.verbs,
+		Strings:       e.strings,
+		Charset:       e.charset,
+		TextStyles:    e.textStyles,
+		TextDefaults:  e.textDefaults,
+		KeepText:      e.keepText,
+		HaveMsg:       e.haveMsg,
+		TalkDelay:     e.talkDelay,
+		Camera:        e.camera,
+		MovingToActor: e.camera.movingToActor,
+		ScreenTop:     e.screenTop,
+		ScreenBottom:  e.screenBottom,
+		Sounds:        e.PlayingSounds(),
+		Music:         e.music,
+		Jiffies:       e.jiffies,
+		Message: savedMessage{
+			Actor: e.message.actor,
+			Style: e.message.style,
+			Rest:  e.message.rest,
+		},
+	}
+
+	slotOf := func(t *Thread) int {
+		for i, th := range e.slots {
+			if th != nil && th == t {
+				return i
+			}
+		}
+		return -1
+	}
+	for i, th := range e.slots {
+		if th == nil || th.status == ThreadDead {
+			continue
+		}
+		st.Slots = append(st.Slots, savedThread{
+			Slot:      i,
+			Script:    th.script.ID,
+			Object:    th.object,
+			Inventory: th.inventory,
+			IP:        th.ip,
+			Local:     th.local,
+			Status:    th.status,
+			Delay:     th.delay,
+		})
+	}
+	for _, cs := range e.cutscenes {
+		st.Cutscenes = append(st.Cutscenes, savedCutscene{
+			Data:    cs.data,
+			Cursor:  cs.cursor,
+			Userput: cs.userput,
+			Slot:    slotOf(cs.thread),
+			Target:  cs.target,
+		})
+	}
+
+	if e.room != nil {
+		st.Boxes = e.room.Boxes
+		for _, ro := range e.room.Objects {
+			st.RoomObjects = append(st.RoomObjects, savedRoomObject{
+				ID:    ro.ID,
+				X:     ro.X,
+				Y:     ro.Y,
+				WalkX: ro.WalkX,
+				WalkY: ro.WalkY,
+			})
+		}
+	}
+
+	for i := range e.actors {
+		a := &e.actors[i]
+		w, c := &a.walk, &a.cost
+		sa := &st.Actors[i]
+		sa.Actor = *a
+		sa.Walk = savedWalk{
+			Dest:    w.dest,
+			DestBox: w.destBox,
+			Dir:     w.dir,
+			From:    w.from,
+			To:      w.to,
+			Box:     w.box,
+			XFrac:   w.xfrac,
+			YFrac:   w.yfrac,
+			DX:      w.dx,
+			DY:      w.dy,
+		}
+		sa.Cost = savedCostume{
+			ID:      c.id,
+			Facing:  c.facing,
+			Pending: c.pending,
+			Stopped: c.stopped,
+		}
+		for j, limb := range c.limbs {
+			sa.Cost.Limbs[j] = savedLimb{
+				Anim:   limb.anim,
+				Pos:    limb.pos,
+				Start:  limb.start,
+				End:    limb.end,
+				NoLoop: limb.noLoop,
+				Shown:  limb.shown,
+			}
+		}
+	}
+
+	for _, obj := range e.objects {
+		st.Objects = append(st.Objects, *obj)
+	}
+	slices.SortFunc(st.Objects, func(a, b Object) int { return int(a.ID - b.ID) })
+	for _, item := range e.inventory {
+		st.Inventory = append(st.Inventory, savedInventoryItem{ID: item.id, Room: item.room})
+	}
+	for _, s := range e.sentences {
+		st.Sentences = append(st.Sentences, savedSentence{Verb: s.verb, ObjA: s.objA, ObjB: s.objB})
+	}
+	for _, t := range e.texts {
+		st.Texts = append(st.Texts, savedText{Text: t, Charset: t.charset})
+	}
+	return st
+}
+
+// loadState restores the saved state. The resources are loaded first, so the state is not changed
+// if any of them is missing.
+func (e *Engine) loadState(st *savedState) error {
+	if len(st.Words) != MaxWords || len(st.Bits) != MaxBits/8 {
+		return fmt.Errorf("invalid number of variables")
+	}
+
+	// The current room and the rooms where the inventory objects came from are loaded again.
+	rooms := make(map[RoomID]*Room)
+	getRoom := func(id RoomID) (*Room, error) {
+		if room, ok := rooms[id]; ok {
+			return room, nil
+		}
+		room, err := e.rm.GetRoom(id, true)
+		if err != nil {
+			return nil, fmt.Errorf("could not load room %d: %w", id, err)
+		}
+		rooms[id] = room
+		return room, nil
+	}
+	var room *Room
+	if st.RoomID != 0 {
+		id := st.RoomID
+		if id.IsPseudo() {
+			id = st.PseudoRooms[id&0x7F]
+		}
+		r, err := getRoom(id)
+		if err != nil {
+			return err
+		}
+		// The current room is a copy, so its objects and boxes can be changed.
+		copied := *r
+		copied.Objects = slices.Clone(r.Objects)
+		copied.Boxes = slices.Clone(st.Boxes)
+		for _, so := range st.RoomObjects {
+			for i := range copied.Objects {
+				if ro := &copied.Objects[i]; ro.ID == so.ID {
+					ro.X, ro.Y, ro.WalkX, ro.WalkY = so.X, so.Y, so.WalkX, so.WalkY
+				}
+			}
+		}
+		room = &copied
+	}
+
+	var inventory []inventoryItem
+	for _, si := range st.Inventory {
+		item := inventoryItem{id: si.ID, room: si.Room}
+		if si.Room != 0 {
+			r, err := getRoom(si.Room)
+			if err != nil {
+				return err
+			}
+			if obj := r.Object(si.ID); obj != nil {
+				code := *obj
+				item.code = &code
+			}
+		}
+		inventory = append(inventory, item)
+	}
+
+	var slots [MaxSlots]*Thread
+	for _, sth := range st.Slots {
+		if sth.Slot < 0 || sth.Slot >= MaxSlots {
+			return fmt.Errorf("invalid slot %d", sth.Slot)
+		}
+		script, err := e.savedScript(sth, room, inventory)
+		if err != nil {
+			return fmt.Errorf("could not restore slot %d: %w", sth.Slot, err)
+		}
+		if sth.IP < 0 || sth.IP > len(script.Code) {
+			return fmt.Errorf("could not restore slot %d: invalid instruction pointer %d",
+				sth.Slot, sth.IP)
+		}
+		th := NewThreadOn(script, sth.IP)
+		copy(th.local, sth.Local)
+		th.object, th.inventory = sth.Object, sth.Inventory
+		th.status, th.delay = sth.Status, sth.Delay
+		slots[sth.Slot] = th
+	}
+
+	var cutscenes []cutscene
+	for _, scs := range st.Cutscenes {
+		cs := cutscene{data: scs.Data, cursor: scs.Cursor, userput: scs.Userput, target: scs.Target}
+		if scs.Slot >= 0 && scs.Slot < MaxSlots {
+			cs.thread = slots[scs.Slot]
+		}
+		cutscenes = append(cutscenes, cs)
+	}
+	if len(cutscenes) == 0 || len(cutscenes) > MaxCutscenes+1 {
+		return fmt.Errorf("invalid number of cutscenes %d", len(cutscenes))
+	}
+
+	// Nothing can fail from here on.
+	e.props = st.Props
+	if e.props == nil {
+		e.props = make(map[Property]int)
+	}
+	e.words = st.Words
+	e.bits = st.Bits
+	e.slots = slots
+	e.cutscenes = cutscenes
+	e.room = room
+	e.roomID = st.RoomID
+	e.pseudoRooms = st.PseudoRooms
+	e.boxMatrix = nil
+	if room != nil {
+		e.CreateBoxMatrix()
+	}
+
+	for i := range e.actors {
+		sa := &st.Actors[i]
+		a := &e.actors[i]
+		*a = sa.Actor
+		a.walk = actorWalk{
+			dest:    sa.Walk.Dest,
+			destBox: sa.Walk.DestBox,
+			dir:     sa.Walk.Dir,
+			from:    sa.Walk.From,
+			to:      sa.Walk.To,
+			box:     sa.Walk.Box,
+			xfrac:   sa.Walk.XFrac,
+			yfrac:   sa.Walk.YFrac,
+			dx:      sa.Walk.DX,
+			dy:      sa.Walk.DY,
+		}
+		a.cost = actorCostume{
+			id:      sa.Cost.ID,
+			facing:  sa.Cost.Facing,
+			pending: sa.Cost.Pending,
+			stopped: sa.Cost.Stopped,
+		}
+		for j, limb := range sa.Cost.Limbs {
+			a.cost.limbs[j] = limbState{
+				anim:   limb.Anim,
+				pos:    limb.Pos,
+				start:  limb.Start,
+				end:    limb.End,
+				noLoop: limb.NoLoop,
+				shown:  limb.Shown,
+			}
+		}
+	}
+
+	e.objects = make(map[ObjectID]*Object, len(st.Objects))
+	for _, obj := range st.Objects {
+		obj := obj
+		e.objects[obj.ID] = &obj
+	}
+	e.inventory = inventory
+	e.verbs = st.Verbs
+	e.sentences = e.sentences[:0]
+	for _, s := range st.Sentences {
+		e.sentences = append(e.sentences, sentence{verb: s.Verb, objA: s.ObjA, objB: s.ObjB})
+	}
+	e.strings = st.Strings
+	e.charset = st.Charset
+
+	e.textStyles = st.TextStyles
+	e.textDefaults = st.TextDefaults
+	e.texts = e.texts[:0]
+	for _, t := range st.Texts {
+		t.Text.charset = t.Charset
+		e.texts = append(e.texts, t.Text)
+	}
+	e.drawTexts()
+	e.message = message{actor: st.Message.Actor, style: st.Message.Style, rest: st.Message.Rest}
+	e.keepText = st.KeepText
+	e.haveMsg = st.HaveMsg
+	e.talkDelay = st.TalkDelay
+
+	e.camera = st.Camera
+	e.camera.movingToActor = st.MovingToActor
+	e.screenTop = st.ScreenTop
+	e.screenBottom = st.ScreenBottom
+	e.jiffies = st.Jiffies
+
+	e.StopMusic()
+	for _, id := range st.Sounds {
+		if err := e.fe.PlaySound(id); err != nil {
+			return fmt.Errorf("cannot play sound %d: %w", id, err)
+		}
+		e.sounds[id] = true
+	}
+	e.music = st.Music
+	return nil
+}
+
+// savedScript returns the script run by a saved thread: the code of an object in the room or in
+// the inventory, a script of the room or a global script.
+func (e *Engine) savedScript(
+	sth savedThread, room *Room, inventory []inventoryItem,
+) (*Script, error) {
+	if sth.Object != 0 {
+		var obj *RoomObject
+		if sth.Inventory {
+			for _, item := range inventory {
+				if item.id == sth.Object {
+					obj = item.code
+				}
+			}
+		} else if room != nil {
+			obj = room.Object(sth.Object)
+		}
+		if obj == nil {
+			return nil, fmt.Errorf("code of object %d not found", sth.Object)
+		}
+		return &obj.Script, nil
+	}
+	if !sth.Script.IsLocal() {
+		return e.loadScript(sth.Script)
+	}
+	var script *Script
+	if room != nil {
+		switch sth.Script {
+		case ScriptIDRoomExit:
+			script = &room.ExitScript
+		case ScriptIDRoomEntry:
+			script = &room.EntryScript
+		default:
+			script = room.LocalScript(sth.Script)
+		}
+	}
+	if script == nil {
+		return nil, fmt.Errorf("local script %d not found", sth.Script)
+	}
+	return script, nil
+}
@@ -0,0 +1,129 @@
+package vm_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoad(t *testing.T) {
+	rm := gameResources{
+		scriptResources: scriptResources{
+			1: {
+				0x1A, 0x64, 0x00, 0x01, 0x00, // 0000: VAR_100 = 1
+				0x72, 0x0A, // 0005: LoadRoom 10
+				0x80,                   // 0007: BreakHere
+				0x33, 0x09, 0x01, 0x03, // 0008: RoomSaveGame 1 3
+				0x80,                         // 000C: BreakHere
+				0x1A, 0x64, 0x00, 0x02, 0x00, // 000D: VAR_100 = 2
+				0xA0, // 0012: StopObjectCode
+			},
+		},
+		rooms: map[vm.RoomID]vm.Room{
+			10: {
+				Width: 640,
+				Boxes: []vm.Box{{
+					UL: vm.Point{X: 0, Y: 0}, UR: vm.Point{X: 639, Y: 0},
+					LR: vm.Point{X: 639, Y: 199}, LL: vm.Point{X: 0, Y: 199},
+				}},
+				Objects: []vm.RoomObject{{ID: 100, X: 8, Y: 16, Width: 8, Height: 8}},
+				EntryScript: vm.Script{Bytecode: []byte{
+					0x0A, 0xC8, 0xFF, // 0000: StartScript 200 []
+					0xA0, // 0003: StopObjectCode
+				}},
+				LocalScripts: []vm.Script{{ID: 200, Bytecode: []byte{
+					0x80,                         // 0000: BreakHere
+					0x80,                         // 0001: BreakHere
+					0x1A, 0x65, 0x00, 0x07, 0x00, // 0002: VAR_101 = 7
+					0xA0, // 0007: StopObjectCode
+				}}},
+			},
+		},
+	}
+	store := vm.NewMemStore()
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	eng.SetStore(store)
+	require.NoError(t, eng.StartScript(1, nil, false))
+	a := eng.Actor(2)
+	a.Room, a.Costume, a.TalkColor = 10, 5, 12
+	eng.PutActor(a, 100, 50)
+	eng.WalkActorTo(a, 300, 50, -1)
+	eng.DrawObject(100, 4, 5)
+	eng.SetBoxFlags(0, vm.BoxXFlip)
+	eng.WriteBit(1000, true)
+	require.NoError(t, eng.LoadString(3, "hello"))
+	v := eng.NewVerb(7)
+	v.Name, v.Mode = "Open", vm.VerbModeOn
+	require.NoError(t, eng.StartMusic(9))
+	require.NoError(t, eng.RunFrame())
+
+	saved, ok := store.File(vm.SaveGameName(3))
+	require.True(t, ok)
+	x := a.X
+	require.NoError(t, eng.RunFrame())
+	assert.Equal(t, 2, eng.ReadWord(100))
+	assert.Equal(t, 7, eng.ReadWord(101))
+
+	fe := vm.NewHeadless(new(vm.FakeClock))
+	restored := vm.NewEngine(rm, fe)
+	require.NoError(t, restored.Load(bytes.NewReader(saved)))
+	assert.Equal(t, vm.SoundID(9), restored.Music())
+	assert.True(t, fe.IsSoundPlaying(9))
+	assert.Equal(t, 1, restored.ReadWord(100))
+	assert.Equal(t, 10, restored.ReadWord(vm.VarRoom))
+	assert.True(t, restored.ReadBit(1000))
+	assert.Equal(t, "hello", restored.ReadString(3))
+	assert.Equal(t, "Open", restored.Verb(7).Name)
+	assert.Equal(t, 4, restored.Jiffies())
+	assert.True(t, restored.IsScriptRunning(1))
+	assert.True(t, restored.IsScriptRunning(200))
+	assert.Equal(t, vm.RoomObject{ID: 100, X: 32, Y: 40, Width: 8, Height: 8, WalkX: 24, WalkY: 24},
+		*restored.Room().Object(100))
+	assert.Equal(t, vm.BoxXFlip, restored.Room().Boxes[0].Flags)
+
+	ra := restored.Actor(2)
+	assert.Equal(t, vm.RoomID(10), ra.Room)
+	assert.Equal(t, 12, ra.TalkColor)
+	assert.Equal(t, x, ra.X)
+	assert.NotZero(t, ra.Moving)
+
+	require.NoError(t, restored.RunFrame())
+	assert.Equal(t, 2, restored.ReadWord(100))
+	assert.Equal(t, 7, restored.ReadWord(101))
+	assert.False(t, restored.IsScriptRunning(1))
+	assert.False(t, restored.IsScriptRunning(200))
+	assert.Equal(t, a.X, ra.X)
+
+	t.Run("Load from slot", func(t *testing.T) {
+		eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+		eng.SetStore(store)
+		eng.SaveLoadGame(vm.SaveLoadLoad, 3)
+		require.NoError(t, eng.RunFrame())
+		assert.Equal(t, 1, eng.ReadWord(100))
+		assert.True(t, eng.IsScriptRunning(1))
+
+		require.NoError(t, eng.SaveLoadError())
+
+		eng.SaveLoadGame(vm.SaveLoadLoad, 4)
+		require.NoError(t, eng.RunFrame())
+		assert.ErrorContains(t, eng.SaveLoadError(), "slot 4")
+		assert.Equal(t, 2, eng.ReadWord(100))
+	})
+
+	t.Run("Invalid data", func(t *testing.T) {
+		eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+		assert.ErrorContains(t, eng.Load(bytes.NewReader([]byte("garbage"))), "not a saved game")
+
+		data := bytes.Clone(saved)
+		data[4] = 0xFF
+		assert.ErrorContains(t, eng.Load(bytes.NewReader(data)), "unsupported version")
+
+		delete(rm.rooms, 10)
+		assert.ErrorContains(t, eng.Load(bytes.NewReader(saved)), "could not load room 10")
+		assert.Equal(t, 0, eng.ReadWord(100))
+	})
+}
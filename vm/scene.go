@@ -0,0 +1,137 @@
+package vm
+
+import "fmt"
+
+// Room returns the current room, or nil if no room is loaded.
+func (e *Engine) Room() *Room {
+	return e.room
+}
+
+// MapPseudoRoom maps the pseudo room with the given ID to the room resource room. IDs that do not
+// refer to pseudo rooms are ignored.
+func (e *Engine) MapPseudoRoom(pseudo, room RoomID) {
+	if pseudo.IsPseudo() {
+		e.pseudoRooms[pseudo&0x7F] = room
+	}
+}
+
+// RoomResource returns the ID of the room resource that backs the given room, resolving the pseudo
+// room mappings if needed.
+func (e *Engine) RoomResource(id RoomID) RoomID {
+	if id.IsPseudo() {
+		return e.pseudoRooms[id&0x7F]
+	}
+	return id
+}
+
+// LoadRoom switches to the room with the given ID. The exit scripts of the current room are run,
+// the threads that belong to it are stopped, the new room is loaded and its entry scripts are run.
+// Room 0 unloads the current room without loading a new one.
+func (e *Engine) LoadRoom(id RoomID) error {
+	return e.startScene(id, nil, 0)
+}
+
+// LoadRoomWithEgo switches to the given room like LoadRoom does, but placing the ego actor at the
+// walking position of the given object before the entry scripts run. If x and y are valid
+// coordinates, the ego walks there afterwards.
+func (e *Engine) LoadRoomWithEgo(obj ObjectID, id RoomID, x, y int) error {
+	ego := e.Actor(ActorID(e.words[VarEgo]))
+	if ego == nil {
+		return fmt.Errorf("cannot load room %d with ego: invalid ego actor %d", id, e.words[VarEgo])
+	}
+	ego.Room = id
+
+	e.words[VarWalkToObj] = int(obj)
+	err := e.startScene(id, ego, obj)
+	e.words[VarWalkToObj] = 0
+	if err != nil {
+		return err
+	}
+
+	if x >= 0 && y >= 0 && x != 0x7FFF && y != 0x7FFF {
+		e.WalkActorTo(ego, x, y, -1)
+	}
+	return nil
+}
+
+func (e *Engine) startScene(id RoomID, ego *Actor, obj ObjectID) error {
+	if e.room != nil {
+		if err := e.runRoomScripts(VarExitScript, &e.room.ExitScript, VarExitScript2); err != nil {
+			return fmt.Errorf("error running exit scripts of room %d: %w", e.room.ID, err)
+		}
+	}
+	e.stopRoomScripts()
+	e.ClearTexts(TextSlotPrint)
+	e.ClearTexts(TextSlotSystem)
+
+	if e.tracer != nil {
+		e.tracer.Trace(RoomChangeEvent{From: e.roomID, To: id})
+	}
+	e.room = nil
+	e.boxMatrix = nil
+	e.roomID = id
+	e.words[VarRoom] = int(id)
+	e.words[VarRoomResource] = int(e.RoomResource(id))
+	if id == 0 {
+		e.words[VarRoomResource] = 0
+		return nil
+	}
+
+	room, err := e.rm.GetRoom(e.RoomResource(id), true)
+	if err != nil {
+		return fmt.Errorf("could not load room %d: %w", id, err)
+	}
+	e.room = room
+	e.CreateBoxMatrix()
+	e.resetCamera()
+
+	if ego != nil {
+		if o := room.Object(obj); o != nil {
+			e.PutActor(ego, o.WalkX, o.WalkY)
+			ego.Facing = (oldDirToNewDir(int(o.ActorDir)) + 180) % 360
+		}
+	}
+
+	if err := e.runRoomScripts(VarEntryScript, &room.EntryScript, VarEntryScript2); err != nil {
+		return fmt.Errorf("error running entry scripts of room %d: %w", id, err)
+	}
+
+	if a := e.Actor(e.camera.Follows); a != nil && a.Room == id {
+		return e.setCameraAt(a.X)
+	}
+	return nil
+}
+
+// runRoomScripts runs the global script set in the pre variable, the given room script and the
+// global script set in the post variable, in that order.
+func (e *Engine) runRoomScripts(pre uint16, script *Script, post uint16) error {
+	if id := e.words[pre]; id != 0 {
+		if err := e.StartScript(ScriptID(id), nil, false); err != nil {
+			return err
+		}
+	}
+	if len(script.Code) > 0 {
+		if err := e.startThread(script, nil); err != nil {
+			return err
+		}
+	}
+	if id := e.words[post]; id != 0 {
+		if err := e.StartScript(ScriptID(id), nil, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stopRoomScripts stops all the threads that run scripts or object code of the current room. The
+// code of the objects in the inventory keeps running.
+func (e *Engine) stopRoomScripts() {
+	for i, th := range e.slots {
+		if th == nil {
+			continue
+		}
+		if th.object != 0 && !th.inventory || th.object == 0 && th.script.ID.IsLocal() {
+			e.stopSlot(i)
+		}
+	}
+}
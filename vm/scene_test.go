@@ -0,0 +1,122 @@
+package vm_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/apoloval/scumm-go/vm4/inst"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gameResources is a resource manager that serves global scripts and rooms from raw bytecode.
+type gameResources struct {
+	scriptResources
+	rooms    map[vm.RoomID]vm.Room
+	costumes map[vm.CostumeID]*vm.Costume
+	charsets map[vm.CharsetID]*vm.Charset
+	objects  map[vm.ObjectID]vm.IndexedObject
+}
+
+func (r gameResources) GetRoom(id vm.RoomID, decode bool) (*vm.Room, error) {
+	tmpl, ok := r.rooms[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown room ID %d", id)
+	}
+	room := &vm.Room{
+		ID:          id,
+		Width:       tmpl.Width,
+		Objects:     append([]vm.RoomObject(nil), tmpl.Objects...),
+		Boxes:       append([]vm.Box(nil), tmpl.Boxes...),
+		ExitScript:  vm.Script{ID: vm.ScriptIDRoomExit, Bytecode: tmpl.ExitScript.Bytecode},
+		EntryScript: vm.Script{ID: vm.ScriptIDRoomEntry, Bytecode: tmpl.EntryScript.Bytecode},
+		Palette:     tmpl.Palette,
+		Background:  tmpl.Background,
+		ZPlanes:     tmpl.ZPlanes,
+	}
+	for _, s := range tmpl.LocalScripts {
+		room.LocalScripts = append(room.LocalScripts, vm.Script{ID: s.ID, Bytecode: s.Bytecode})
+	}
+	if decode {
+		return room, room.Decode(inst.Decode)
+	}
+	return room, nil
+}
+
+func (r gameResources) GetObjects() map[vm.ObjectID]vm.IndexedObject {
+	return r.objects
+}
+
+func (r gameResources) GetObjectRoom(id vm.ObjectID) (vm.RoomID, error) {
+	for rid, room := range r.rooms {
+		if room.Object(id) != nil {
+			return rid, nil
+		}
+	}
+	return r.scriptResources.GetObjectRoom(id)
+}
+
+func (r gameResources) GetCostume(id vm.CostumeID) (*vm.Costume, error) {
+	if cost, ok := r.costumes[id]; ok {
+		return cost, nil
+	}
+	return r.scriptResources.GetCostume(id)
+}
+
+func (r gameResources) GetCharset(id vm.CharsetID) (*vm.Charset, error) {
+	if cs, ok := r.charsets[id]; ok {
+		return cs, nil
+	}
+	return r.scriptResources.GetCharset(id)
+}
+
+func TestLoadRoom(t *testing.T) {
+	rm := gameResources{
+		scriptResources: scriptResources{
+			1: {
+				0x1A, 0x1E, 0x00, 0x02, 0x00, // 0000: VAR_EXIT_SCRIPT = 2
+				0x1A, 0x1C, 0x00, 0x03, 0x00, // 0005: VAR_ENTRY_SCRIPT = 3
+				0xCC, 0x0A, 0x81, 0x00, // 000A: PseudoRoom 10 [129]
+				0x72, 0x0A, // 000E: LoadRoom 10
+				0x72, 0x81, // 0010: LoadRoom 129
+				0xA0, // 0012: StopObjectCode
+			},
+			2: {
+				0x9A, 0x64, 0x00, 0x04, 0x00, // 0000: VAR_100 = VAR_ROOM
+				0xA0, // 0005: StopObjectCode
+			},
+			3: {
+				0x9A, 0x65, 0x00, 0x04, 0x00, // 0000: VAR_101 = VAR_ROOM
+				0xA0, // 0005: StopObjectCode
+			},
+		},
+		rooms: map[vm.RoomID]vm.Room{
+			10: {
+				ExitScript: vm.Script{Bytecode: []byte{
+					0x1A, 0x66, 0x00, 0x01, 0x00, // 0000: VAR_102 = 1
+					0xA0, // 0005: StopObjectCode
+				}},
+				EntryScript: vm.Script{Bytecode: []byte{
+					0x0A, 0xC8, 0xFF, // 0000: StartScript 200 []
+					0xA0, // 0003: StopObjectCode
+				}},
+				LocalScripts: []vm.Script{{ID: 200, Bytecode: []byte{
+					0x80,             // 0000: BreakHere
+					0x18, 0xFC, 0xFF, // 0001: Jump 0000
+				}}},
+			},
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	assert.Equal(t, 0x81, eng.ReadWord(vm.VarRoom))
+	assert.Equal(t, 10, eng.ReadWord(vm.VarRoomResource))
+	assert.Equal(t, vm.RoomID(10), eng.Room().ID)
+	assert.Equal(t, 10, eng.ReadWord(100))
+	assert.Equal(t, 0x81, eng.ReadWord(101))
+	assert.Equal(t, 1, eng.ReadWord(102))
+	assert.True(t, eng.IsScriptRunning(200))
+}
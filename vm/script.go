@@ -10,6 +10,23 @@ import (
 // ScriptID is the ID of a script.
 type ScriptID int
 
+const (
+	// MaxGlobalScripts is the number of global scripts. Scripts with a greater ID are local to the
+	// current room.
+	MaxGlobalScripts ScriptID = 200
+
+	// ScriptIDRoomExit is the pseudo ID of the exit script of the current room.
+	ScriptIDRoomExit ScriptID = 10001
+
+	// ScriptIDRoomEntry is the pseudo ID of the entry script of the current room.
+	ScriptIDRoomEntry ScriptID = 10002
+)
+
+// IsLocal returns true if the script ID refers to a local script of the current room.
+func (id ScriptID) IsLocal() bool {
+	return id >= MaxGlobalScripts
+}
+
 // ParseScriptID parses a string into a script ID.
 func ParseScriptID(s string) (ScriptID, error) {
 	id, err := strconv.Atoi(s)
@@ -34,8 +51,12 @@ type Script struct {
 	Frames []BytecodeFrame
 }
 
-// Decode decodes the script bytecode using the given instruction decoder.
+// Decode decodes the script bytecode using the given instruction decoder. Empty scripts are left
+// as they are.
 func (s *Script) Decode(dec InstructionDecoder) (err error) {
+	if len(s.Bytecode) == 0 {
+		return nil
+	}
 	r := NewBytecodeDecoder(bytes.NewReader(s.Bytecode))
 	for {
 		r.BeginFrame()
@@ -104,10 +125,17 @@ func (s Script) checkBranchConsistency(st *SymbolTable) error {
 }
 
 func (s Script) instructionOnAddress(addr uint16) Instruction {
+	if i := s.indexOf(addr); i >= 0 {
+		return s.Code[i]
+	}
+	return nil
+}
+
+func (s Script) indexOf(addr uint16) int {
 	for i, frame := range s.Frames {
 		if frame.StartAddress == addr {
-			return s.Code[i]
+			return i
 		}
 	}
-	return nil
+	return -1
 }
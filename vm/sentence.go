@@ -0,0 +1,125 @@
+package vm
+
+import "fmt"
+
+// MaxSentences is the maximum number of sentences waiting to be executed.
+const MaxSentences = 6
+
+// sentence is a verb applied to one or two objects, like "use key with door".
+type sentence struct {
+	verb VerbID
+	objA ObjectID
+	objB ObjectID
+}
+
+// DoSentence pushes a sentence to be executed by the sentence script set in VarSentenceScript. The
+// sentences are stacked, so the last one pushed is the first one executed.
+func (e *Engine) DoSentence(verb VerbID, objA, objB ObjectID) error {
+	if len(e.sentences) >= MaxSentences {
+		return fmt.Errorf("cannot do sentence %d: too many pending sentences", verb)
+	}
+	e.sentences = append(e.sentences, sentence{verb: verb, objA: objA, objB: objB})
+	return nil
+}
+
+// StopSentence discards the pending sentences and stops the sentence script.
+func (e *Engine) StopSentence() {
+	e.sentences = e.sentences[:0]
+	if script := e.words[VarSentenceScript]; script != 0 {
+		e.StopScript(ScriptID(script))
+	}
+}
+
+// IsSentenceRunning returns true if there are sentences waiting to be executed or the sentence
+// script is running.
+func (e *Engine) IsSentenceRunning() bool {
+	return len(e.sentences) > 0 || e.IsScriptRunning(ScriptID(e.words[VarSentenceScript]))
+}
+
+// runSentence pops the last pending sentence and starts the sentence script with the verb and the
+// objects as arguments, unless the sentence script is still running the previous one. Sentences
+// applying an object to itself are discarded.
+func (e *Engine) runSentence() error {
+	script := ScriptID(e.words[VarSentenceScript])
+	if len(e.sentences) == 0 || e.IsScriptRunning(script) {
+		return nil
+	}
+	st := e.sentences[len(e.sentences)-1]
+	e.sentences = e.sentences[:len(e.sentences)-1]
+	if script == 0 || st.objB != 0 && st.objB == st.objA {
+		return nil
+	}
+	return e.StartScript(script, []int{int(st.verb), int(st.objA), int(st.objB)}, false)
+}
+
+// VerbEntrypoint returns the offset of the code the object runs for the given verb, or 0 if the
+// object does not react to it. The entry for the default verb matches any verb.
+func (e *Engine) VerbEntrypoint(id ObjectID, verb VerbID) (int, error) {
+	obj, _, err := e.objectCode(id)
+	if err != nil || obj == nil {
+		return 0, err
+	}
+	if v := obj.verb(verb); v != nil {
+		return int(obj.CodeOffset) + int(v.Address), nil
+	}
+	return 0, nil
+}
+
+// StartObject starts a new thread running the code of the object for the given verb, passing args
+// as its first local variables. Unless recursive is set, any running script of the same object is
+// stopped first. Nothing is done if the object does not react to the verb.
+func (e *Engine) StartObject(id ObjectID, verb VerbID, args []int, recursive bool) error {
+	if id == 0 {
+		return nil
+	}
+	if !recursive {
+		e.StopObjectScript(id)
+	}
+	obj, inventory, err := e.objectCode(id)
+	if err != nil || obj == nil {
+		return err
+	}
+	v := obj.verb(verb)
+	if v == nil {
+		return nil
+	}
+	ip := obj.Script.indexOf(v.Address)
+	if ip < 0 {
+		return fmt.Errorf("cannot start object %d: invalid entry point %04X for verb %d",
+			id, v.Address, verb)
+	}
+	slot := e.freeSlot()
+	if slot < 0 {
+		return fmt.Errorf("cannot start object %d: no free slots", id)
+	}
+	th := NewThreadOn(&obj.Script, ip)
+	th.object, th.inventory = id, inventory
+	for i, arg := range args {
+		if i < MaxLocals {
+			th.local[i] = arg
+		}
+	}
+	return e.runNewThread(slot, th)
+}
+
+// StopObjectScript stops all the threads running the code of the given object.
+func (e *Engine) StopObjectScript(id ObjectID) {
+	for i, th := range e.slots {
+		if th != nil && th.object != 0 && th.object == id {
+			e.stopSlot(i)
+		}
+	}
+}
+
+// objectCode returns the code of the given object, looking in the inventory for the objects owned
+// by actors and in the current room otherwise. The inventory result tells where it was found.
+func (e *Engine) objectCode(id ObjectID) (code *RoomObject, inventory bool, err error) {
+	if owner := e.Object(id).Owner; owner != 0 && owner != ObjectOwnerRoom {
+		code, err := e.inventoryCode(id)
+		return code, true, err
+	}
+	if e.room != nil {
+		return e.room.Object(id), false, nil
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,58 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoSentence(t *testing.T) {
+	rm := gameResources{
+		scriptResources: scriptResources{
+			1: {
+				0x1A, 0x21, 0x00, 0x02, 0x00, // 0000: VAR_SENTENCE_SCRIPT = 2
+				0x72, 0x0A, // 0005: LoadRoom 10
+				0x19, 0x05, 0x64, 0x00, 0x00, 0x00, // 0007: DoSentence 5 100 0
+				0xAE, 0x04, // 000D: WaitForSentence
+				0xA0, // 000F: StopObjectCode
+			},
+			2: {
+				0xCB, 0x69, 0x00, 0x01, 0x40, 0x00, 0x40, // 0000: VAR_105 = GetVerbEntrypoint L1 L0
+				0xF7, 0x01, 0x40, 0x00, 0x40, 0x01, 0x2A, 0x00, 0xFF, // 0007: StartObject L1 L0 [42]
+				0xA0, // 0010: StopObjectCode
+			},
+		},
+		rooms: map[vm.RoomID]vm.Room{
+			10: {
+				Objects: []vm.RoomObject{{
+					ID:         100,
+					Verbs:      []vm.ObjectVerb{{Verb: 5, Address: 0}},
+					CodeOffset: 20,
+					Script: vm.Script{Bytecode: []byte{
+						0x9A, 0x6A, 0x00, 0x00, 0x40, // 0000: VAR_106 = L0
+						0x80,                         // 0005: BreakHere
+						0x1A, 0x6B, 0x00, 0x01, 0x00, // 0006: VAR_107 = 1
+						0xA0, // 000B: StopObjectCode
+					}},
+				}},
+			},
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+	assert.True(t, eng.IsScriptRunning(1))
+	assert.True(t, eng.IsSentenceRunning())
+
+	require.NoError(t, eng.RunFrame())
+	assert.Equal(t, 20, eng.ReadWord(105))
+	assert.Equal(t, 42, eng.ReadWord(106))
+	assert.Equal(t, 1, eng.ReadWord(107))
+	assert.False(t, eng.IsSentenceRunning())
+	assert.False(t, eng.IsScriptRunning(1))
+	entry, err := eng.VerbEntrypoint(100, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 0, entry)
+}
@@ -1,4 +1,143 @@
 package vm
 
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
 // SoundID is the ID of a sound.
 type SoundID int
+
+// Sound is a sound resource, with the data of the sound for each of the devices that play it.
+type Sound struct {
+	ID SoundID
+
+	// AdLib, Roland and Speaker are the data of the sound for the AdLib, the Roland and the PC
+	// speaker devices, or nil if the sound is not available for the device.
+	AdLib   []byte
+	Roland  []byte
+	Speaker []byte
+}
+
+// StartSound starts playing the given sound through the frontend. The sound becomes the last sound
+// played, but unlike StartMusic the music timer is not reset.
+func (e *Engine) StartSound(id SoundID) error {
+	return e.playSound(id)
+}
+
+// StartMusic starts playing the given sound as the music, replacing the music being played.
+func (e *Engine) StartMusic(id SoundID) error {
+	if e.music != 0 {
+		e.StopSound(e.music)
+	}
+	if err := e.playSound(id); err != nil {
+		return err
+	}
+	e.music = id
+	e.words[VarMusicTimer] = 0
+	return nil
+}
+
+// StopSound stops playing the given sound.
+func (e *Engine) StopSound(id SoundID) {
+	if !e.sounds[id] {
+		return
+	}
+	e.fe.StopSound(id)
+	delete(e.sounds, id)
+	if e.music == id {
+		e.music = 0
+	}
+}
+
+// StopMusic stops playing the music, along with any other sound.
+func (e *Engine) StopMusic() {
+	for _, id := range e.PlayingSounds() {
+		e.StopSound(id)
+	}
+}
+
+// IsSoundRunning returns true if the given sound was started and it is still being played.
+func (e *Engine) IsSoundRunning(id SoundID) bool {
+	return e.sounds[id] && e.fe.IsSoundPlaying(id)
+}
+
+// PlayingSounds returns the sounds started and not stopped yet, sorted by ID.
+func (e *Engine) PlayingSounds() []SoundID {
+	ids := make([]SoundID, 0, len(e.sounds))
+	for id := range e.sounds {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Music returns the sound played as music, or 0 if there is none.
+func (e *Engine) Music() SoundID {
+	return e.music
+}
+
+func (e *Engine) playSound(id SoundID) error {
+	if id == 0 {
+		return nil
+	}
+	if err := e.fe.PlaySound(id); err != nil {
+		return fmt.Errorf("cannot play sound %d: %w", id, err)
+	}
+	e.sounds[id] = true
+	e.words[VarLastSound] = int(id)
+	return nil
+}
+
+// updateSounds forgets the sounds that the frontend finished playing, and advances the music timer
+// by the given jiffies while the music is playing.
+func (e *Engine) updateSounds(delta int) {
+	for id := range e.sounds {
+		if !e.fe.IsSoundPlaying(id) {
+			delete(e.sounds, id)
+			if e.music == id {
+				e.music = 0
+			}
+		}
+	}
+	if e.music != 0 {
+		e.words[VarMusicTimer] += delta
+	}
+}
+
+// SoundEvent is a sound started or stopped at some time of the clock of the frontend.
+type SoundEvent struct {
+	Time  time.Duration
+	Sound SoundID
+	Start bool
+}
+
+// SoundRecorder is a frontend that records the sounds started and stopped by the engine before
+// passing them to the wrapped frontend.
+type SoundRecorder struct {
+	Frontend
+	events []SoundEvent
+}
+
+// NewSoundRecorder returns a frontend that records the sounds played through fe.
+func NewSoundRecorder(fe Frontend) *SoundRecorder {
+	return &SoundRecorder{Frontend: fe}
+}
+
+// PlaySound implements the Frontend interface.
+func (r *SoundRecorder) PlaySound(id SoundID) error {
+	r.events = append(r.events, SoundEvent{Time: r.Now(), Sound: id, Start: true})
+	return r.Frontend.PlaySound(id)
+}
+
+// StopSound implements the Frontend interface.
+func (r *SoundRecorder) StopSound(id SoundID) {
+	r.events = append(r.events, SoundEvent{Time: r.Now(), Sound: id})
+	r.Frontend.StopSound(id)
+}
+
+// Events returns the sounds started and stopped so far, in order.
+func (r *SoundRecorder) Events() []SoundEvent {
+	return r.events
+}
@@ -0,0 +1,56 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSound(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x02, 0x05, // 0000: StartMusic 5
+			0x1C, 0x07, // 0002: StartSound 7
+			0x7C, 0x64, 0x00, 0x07, // 0004: VAR_100 = IsSoundRunning 7
+			0x80,       // 0008: BreakHere
+			0x3C, 0x07, // 0009: StopSound 7
+			0x7C, 0x65, 0x00, 0x07, // 000B: VAR_101 = IsSoundRunning 7
+			0x80, // 000F: BreakHere
+			0x20, // 0010: StopMusic
+			0xA0, // 0011: StopObjectCode
+		},
+	}
+	fe := vm.NewSoundRecorder(vm.NewHeadless(new(vm.FakeClock)))
+	eng := vm.NewEngine(rm, fe)
+	eng.WriteWord(vm.VarMusicTimer, 100)
+	require.NoError(t, eng.StartScript(1, nil, false))
+	assert.Equal(t, []vm.SoundID{5, 7}, eng.PlayingSounds())
+	assert.Equal(t, vm.SoundID(5), eng.Music())
+	assert.Equal(t, 7, eng.ReadWord(vm.VarLastSound))
+	assert.Equal(t, 0, eng.ReadWord(vm.VarMusicTimer))
+	assert.Equal(t, 1, eng.ReadWord(100))
+
+	require.NoError(t, eng.RunFrame())
+	assert.Equal(t, []vm.SoundID{5}, eng.PlayingSounds())
+	assert.Equal(t, 4, eng.ReadWord(vm.VarMusicTimer))
+	assert.Equal(t, 0, eng.ReadWord(101))
+
+	require.NoError(t, eng.RunFrame())
+	assert.Empty(t, eng.PlayingSounds())
+	assert.Equal(t, vm.SoundID(0), eng.Music())
+
+	require.NoError(t, eng.RunFrame())
+	assert.Equal(t, 8, eng.ReadWord(vm.VarMusicTimer))
+
+	assert.Equal(t, []vm.SoundEvent{
+		{Time: 0, Sound: 5, Start: true},
+		{Time: 0, Sound: 7, Start: true},
+		{Time: 4 * vm.Jiffy, Sound: 7},
+		{Time: 8 * vm.Jiffy, Sound: 5},
+	}, fe.Events())
+
+	require.NoError(t, eng.StartSound(3))
+	assert.Equal(t, 8, eng.ReadWord(vm.VarMusicTimer))
+}
@@ -0,0 +1,98 @@
+package vm
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// PITFrequency is the frequency in Hz of the clock of the programmable interval timer, whose
+// divided output drives the PC speaker.
+const PITFrequency = 1193182
+
+// speakerAmplitude is the amplitude of the square wave of the PC speaker in the rendered PCM.
+const speakerAmplitude = 0x2000
+
+// SpeakerTone is a tone played by the PC speaker.
+type SpeakerTone struct {
+	// Divisor is the divisor applied to PITFrequency to obtain the frequency of the tone, or 0 for
+	// silence.
+	Divisor uint16
+
+	// Jiffies is the duration of the tone.
+	Jiffies int
+}
+
+// Frequency returns the frequency of the tone in Hz, or 0 for silence.
+func (t SpeakerTone) Frequency() float64 {
+	if t.Divisor == 0 {
+		return 0
+	}
+	return PITFrequency / float64(t.Divisor)
+}
+
+// RenderSpeaker renders the tones played by the PC speaker as a square wave in signed 16-bit PCM
+// samples at the given sample rate. The wave keeps its phase from one tone to the next.
+func RenderSpeaker(tones []SpeakerTone, rate int) []int16 {
+	var samples []int16
+	var elapsed time.Duration
+	var phase float64
+	for _, tone := range tones {
+		elapsed += time.Duration(tone.Jiffies) * Jiffy
+		end := int(elapsed * time.Duration(rate) / time.Second)
+		step := tone.Frequency() / float64(rate)
+		for len(samples) < end {
+			switch {
+			case step == 0:
+				samples = append(samples, 0)
+			case phase < 0.5:
+				samples = append(samples, speakerAmplitude)
+			default:
+				samples = append(samples, -speakerAmplitude)
+			}
+			phase += step
+			phase -= float64(int(phase))
+		}
+	}
+	return samples
+}
+
+// WriteWAV writes the signed 16-bit PCM samples of a mono sound at the given sample rate as a WAV
+// file.
+func WriteWAV(w io.Writer, rate int, samples []int16) error {
+	const bytesPerSample = 2
+	size := uint32(len(samples) * bytesPerSample)
+	header := struct {
+		RIFF          [4]byte
+		RIFFSize      uint32
+		WAVE          [4]byte
+		Fmt           [4]byte
+		FmtSize       uint32
+		Format        uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+		Data          [4]byte
+		DataSize      uint32
+	}{
+		RIFF:          [4]byte{'R', 'I', 'F', 'F'},
+		RIFFSize:      36 + size,
+		WAVE:          [4]byte{'W', 'A', 'V', 'E'},
+		Fmt:           [4]byte{'f', 'm', 't', ' '},
+		FmtSize:       16,
+		Format:        1,
+		Channels:      1,
+		SampleRate:    uint32(rate),
+		ByteRate:      uint32(rate * bytesPerSample),
+		BlockAlign:    bytesPerSample,
+		BitsPerSample: 8 * bytesPerSample,
+		Data:          [4]byte{'d', 'a', 't', 'a'},
+		DataSize:      size,
+	}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, samples)
+}
@@ -0,0 +1,37 @@
+package vm_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderSpeaker(t *testing.T) {
+	tones := []vm.SpeakerTone{
+		{Divisor: 1193, Jiffies: 1},
+		{Divisor: 0, Jiffies: 1},
+	}
+	assert.InDelta(t, 1000.0, tones[0].Frequency(), 0.5)
+
+	samples := vm.RenderSpeaker(tones, 8000)
+	require.Len(t, samples, 266)
+	assert.Equal(t, []int16{
+		0x2000, 0x2000, 0x2000, 0x2000, -0x2000, -0x2000, -0x2000, -0x2000, 0x2000,
+	}, samples[:9])
+	assert.Equal(t, make([]int16, 133), samples[133:])
+
+	var wav bytes.Buffer
+	require.NoError(t, vm.WriteWAV(&wav, 8000, samples))
+	data := wav.Bytes()
+	require.Len(t, data, 44+2*266)
+	assert.Equal(t, "RIFF", string(data[0:4]))
+	assert.Equal(t, "WAVEfmt ", string(data[8:16]))
+	assert.Equal(t, uint32(8000), binary.LittleEndian.Uint32(data[24:]))
+	assert.Equal(t, "data", string(data[36:40]))
+	assert.Equal(t, uint32(2*266), binary.LittleEndian.Uint32(data[40:]))
+	assert.Equal(t, int16(-0x2000), int16(binary.LittleEndian.Uint16(data[44+2*4:])))
+}
@@ -0,0 +1,103 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Store keeps the files written by the games, like the saved games.
+type Store interface {
+	// Create opens the file with the given name for writing, replacing its previous content. The
+	// content is stored when the writer is closed.
+	Create(name string) (io.WriteCloser, error)
+
+	// Open opens the file with the given name for reading. If the file does not exist, the error
+	// wraps fs.ErrNotExist.
+	Open(name string) (io.ReadCloser, error)
+}
+
+// DirStore is a store that keeps the files in a directory. The names of the files cannot refer to
+// paths outside of it.
+type DirStore struct {
+	dir string
+}
+
+// NewDirStore returns a store that keeps the files in the given directory, which is created when
+// the first file is written.
+func NewDirStore(dir string) *DirStore {
+	return &DirStore{dir: dir}
+}
+
+// Create implements the Store interface.
+func (s *DirStore) Create(name string) (io.WriteCloser, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, err
+	}
+	return os.Create(path)
+}
+
+// Open implements the Store interface.
+func (s *DirStore) Open(name string) (io.ReadCloser, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+func (s *DirStore) path(name string) (string, error) {
+	if !filepath.IsLocal(name) || filepath.Base(name) != name {
+		return "", fmt.Errorf("invalid file name %q", name)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+// MemStore is a store that keeps the files in memory.
+type MemStore struct {
+	files map[string][]byte
+}
+
+// NewMemStore returns an empty in-memory store.
+func NewMemStore() *MemStore {
+	return &MemStore{files: make(map[string][]byte)}
+}
+
+// Create implements the Store interface.
+func (s *MemStore) Create(name string) (io.WriteCloser, error) {
+	return &memFile{store: s, name: name}, nil
+}
+
+// Open implements the Store interface.
+func (s *MemStore) Open(name string) (io.ReadCloser, error) {
+	data, ok := s.File(name)
+	if !ok {
+		return nil, fmt.Errorf("cannot open %q: %w", name, fs.ErrNotExist)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// File returns the content of the file with the given name, if it exists.
+func (s *MemStore) File(name string) ([]byte, bool) {
+	data, ok := s.files[name]
+	return data, ok
+}
+
+// memFile is a file of a MemStore being written.
+type memFile struct {
+	bytes.Buffer
+	store *MemStore
+	name  string
+}
+
+func (f *memFile) Close() error {
+	f.store.files[f.name] = bytes.Clone(f.Bytes())
+	return nil
+}
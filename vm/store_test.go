@@ -0,0 +1,45 @@
+package vm_test
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "saves")
+	store := vm.NewDirStore(dir)
+
+	_, err := store.Open("game.sav")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	w, err := store.Create("game.sav")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(filepath.Join(dir, "game.sav"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	r, err := store.Open("game.sav")
+	require.NoError(t, err)
+	data, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	require.NoError(t, r.Close())
+
+	for _, name := range []string{"../game.sav", "/tmp/game.sav", "sub/game.sav", ""} {
+		_, err := store.Create(name)
+		assert.ErrorContains(t, err, "invalid file name", name)
+		_, err = store.Open(name)
+		assert.ErrorContains(t, err, "invalid file name", name)
+	}
+}
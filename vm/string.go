@@ -0,0 +1,111 @@
+package vm
+
+import "fmt"
+
+// StringID is the ID of a string slot.
+type StringID int
+
+// MaxStrings is the number of string slots. Slot 0 is never used.
+const MaxStrings = 50
+
+// NewString allocates the string slot with the given size, filled with zeros. A size of 0 frees the
+// slot.
+func (e *Engine) NewString(id StringID, size int) error {
+	if err := e.checkString(id); err != nil {
+		return err
+	}
+	e.strings[id] = nil
+	if size > 0 {
+		e.strings[id] = make([]byte, size)
+	}
+	return nil
+}
+
+// LoadString sets the content of the string slot to the given text, allocating the slot with the
+// size of the text plus its null terminator.
+func (e *Engine) LoadString(id StringID, text string) error {
+	if err := e.checkString(id); err != nil {
+		return err
+	}
+	e.strings[id] = append([]byte(text), 0)
+	return nil
+}
+
+// CopyString copies the content of the string slot src into dst. If src is not allocated, dst is
+// freed.
+func (e *Engine) CopyString(dst, src StringID) error {
+	if err := e.checkString(dst); err != nil {
+		return err
+	}
+	if err := e.checkString(src); err != nil {
+		return err
+	}
+	e.strings[dst] = nil
+	if e.strings[src] != nil {
+		e.strings[dst] = append([]byte(nil), e.strings[src]...)
+	}
+	return nil
+}
+
+// WriteChar writes a character at the given position of the string slot.
+func (e *Engine) WriteChar(id StringID, idx int, ch byte) error {
+	str, err := e.allocatedString(id)
+	if err != nil {
+		return err
+	}
+	if idx < 0 || idx >= len(str) {
+		return fmt.Errorf("cannot write char %d of string %d: out of bounds", idx, id)
+	}
+	str[idx] = ch
+	return nil
+}
+
+// ReadChar returns the character at the given position of the string slot.
+func (e *Engine) ReadChar(id StringID, idx int) (byte, error) {
+	str, err := e.allocatedString(id)
+	if err != nil {
+		return 0, err
+	}
+	if idx < 0 || idx >= len(str) {
+		return 0, fmt.Errorf("cannot read char %d of string %d: out of bounds", idx, id)
+	}
+	return str[idx], nil
+}
+
+// ReadString returns the text of the string slot, up to its null terminator. Slots that are not
+// allocated read as an empty string.
+func (e *Engine) ReadString(id StringID) string {
+	if e.checkString(id) != nil {
+		return ""
+	}
+	str := e.strings[id]
+	for i, ch := range str {
+		if ch == 0 {
+			return string(str[:i])
+		}
+	}
+	return string(str)
+}
+
+// StringWidth returns the width of the text when printed with the current charset.
+func (e *Engine) StringWidth(text string) int {
+	width, _ := e.textSize(text)
+	return width
+}
+
+func (e *Engine) allocatedString(id StringID) ([]byte, error) {
+	if err := e.checkString(id); err != nil {
+		return nil, err
+	}
+	if e.strings[id] == nil {
+		return nil, fmt.Errorf("string %d is not allocated", id)
+	}
+	return e.strings[id], nil
+}
+
+func (e *Engine) checkString(id StringID) error {
+	if id < 1 || id >= MaxStrings {
+		return fmt.Errorf("invalid string ID %d", id)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringOps(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x27, 0x01, 0x01, 'H', 'i', 0x00, // 0000: LoadString 1 "Hi"
+			0x27, 0x02, 0x02, 0x01, // 0006: CopyString 2 1
+			0x27, 0x03, 0x02, 0x01, 'o', // 000A: WriteChar 2 1 'o'
+			0x27, 0x04, 0x64, 0x00, 0x02, 0x00, // 000F: VAR_100 = ReadChar 2 0
+			0x27, 0x05, 0x03, 0x04, // 0015: NewString 3 4
+			0x27, 0x04, 0x65, 0x00, 0x03, 0x03, // 0019: VAR_101 = ReadChar 3 3
+			0x67, 0x66, 0x00, 0x01, // 001F: VAR_102 = StringWidth 1
+			0xA0, // 0023: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	assert.Equal(t, "Hi", eng.ReadString(1))
+	assert.Equal(t, "Ho", eng.ReadString(2))
+	assert.Equal(t, "", eng.ReadString(3))
+	assert.Equal(t, int('H'), eng.ReadWord(100))
+	assert.Equal(t, 0, eng.ReadWord(101))
+	assert.Equal(t, 16, eng.ReadWord(102))
+
+	_, err := eng.ReadChar(3, 4)
+	assert.Error(t, err)
+	assert.Error(t, eng.WriteChar(4, 0, 'x'))
+}
+
+func TestCharsetStringWidth(t *testing.T) {
+	var cs vm.Charset
+	cs.Characters['a'] = &vm.Character{Width: 5, XOffset: 1}
+	cs.Characters['b'] = &vm.Character{Width: 7}
+
+	assert.Equal(t, 13, cs.StringWidth("ab"))
+	assert.Equal(t, 14, cs.StringWidth("a\xFF\x01bb"))
+	assert.Equal(t, 6, cs.StringWidth("a\xFF\x04\x10\x00"))
+}
@@ -0,0 +1,280 @@
+package vm
+
+import (
+	"image"
+	"strconv"
+)
+
+const (
+	// ScreenWidth and ScreenHeight are the size of the game screen in pixels.
+	ScreenWidth  = 320
+	ScreenHeight = 200
+)
+
+// TextSlot is one of the text styles kept by the engine. The slot is selected by the actor passed
+// to the print instructions.
+type TextSlot int
+
+const (
+	// TextSlotTalk is the slot of the messages spoken by actors.
+	TextSlotTalk TextSlot = iota
+
+	// TextSlotPrint is the slot of the texts printed on screen, selected by actor 0xFE.
+	TextSlotPrint
+
+	// TextSlotDebug is the slot of the debug messages, selected by actor 0xFD. They are not shown.
+	TextSlotDebug
+
+	// TextSlotSystem is the slot of the system messages, selected by actor 0xFC.
+	TextSlotSystem
+
+	numTextSlots
+)
+
+// TextSlotFor returns the text slot used to print on behalf of the given actor.
+func TextSlotFor(actor int) TextSlot {
+	switch actor {
+	case 0xFC:
+		return TextSlotSystem
+	case 0xFD:
+		return TextSlotDebug
+	case 0xFE:
+		return TextSlotPrint
+	default:
+		return TextSlotTalk
+	}
+}
+
+// TextStyle is the way a text is laid out on screen.
+type TextStyle struct {
+	// X and Y are the position of the text. If the text is centered, X is its horizontal center.
+	X int
+	Y int
+
+	Color int
+
+	// Right is the right limit of the text. The lines are wrapped to fit in it.
+	Right int
+
+	Center bool
+
+	// Overhead places the messages spoken by actors above their heads.
+	Overhead bool
+}
+
+func defaultTextStyle() TextStyle {
+	return TextStyle{X: 2, Y: 5, Color: 15, Right: ScreenWidth - 1}
+}
+
+// Text is a line of text shown on screen.
+type Text struct {
+	Slot   TextSlot
+	X      int
+	Y      int
+	Color  int
+	String string
+
+	charset CharsetID
+}
+
+// TextStyle returns the current style of the given text slot.
+func (e *Engine) TextStyle(slot TextSlot) *TextStyle {
+	return &e.textStyles[slot]
+}
+
+// LoadTextStyle resets the style of the given text slot to its defaults and returns it.
+func (e *Engine) LoadTextStyle(slot TextSlot) *TextStyle {
+	e.textStyles[slot] = e.textDefaults[slot]
+	return &e.textStyles[slot]
+}
+
+// SaveTextStyle makes the current style of the given text slot its default.
+func (e *Engine) SaveTextStyle(slot TextSlot) {
+	e.textDefaults[slot] = e.textStyles[slot]
+}
+
+// Print shows a text on behalf of thread t with the style of the text slot of the given actor.
+// The escape codes that insert variables, verbs, names and strings are expanded. Actors other than
+// the ones that select a text slot speak the text as a message, which replaces the previous one
+// unless it was kept.
+func (e *Engine) Print(t *Thread, actor int, text string) error {
+	msg := e.expandText(t, text)
+	slot := TextSlotFor(actor)
+	switch slot {
+	case TextSlotTalk:
+		e.talk(actor, msg)
+	case TextSlotDebug:
+	default:
+		lines, _, _ := splitMessage(msg)
+		style := e.textStyles[slot]
+		e.drawLines(slot, style, e.wrapLines(style, lines))
+	}
+	return nil
+}
+
+// Texts returns the lines of text shown on screen.
+func (e *Engine) Texts() []Text {
+	return e.texts
+}
+
+// TextLayer returns the image where the texts are drawn. Color 0 is transparent.
+func (e *Engine) TextLayer() *image.Paletted {
+	return e.textLayer
+}
+
+// ClearTexts removes the lines of text of the given slot from the screen.
+func (e *Engine) ClearTexts(slot TextSlot) {
+	texts := e.texts[:0]
+	for _, t := range e.texts {
+		if t.Slot != slot {
+			texts = append(texts, t)
+		}
+	}
+	e.texts = texts
+	e.drawTexts()
+}
+
+// drawLines shows the given lines with the given style.
+func (e *Engine) drawLines(slot TextSlot, style TextStyle, lines []string) {
+	y := style.Y
+	for _, line := range lines {
+		width, height := e.textSize(line)
+		x := style.X
+		if style.Center {
+			x -= width / 2
+		}
+		x = max(min(x, ScreenWidth-width), 0)
+		e.texts = append(e.texts, Text{
+			Slot:    slot,
+			X:       x,
+			Y:       y,
+			Color:   style.Color,
+			String:  line,
+			charset: e.charset,
+		})
+		y += height
+	}
+	e.drawTexts()
+}
+
+// drawTexts draws again the text layer with the lines of text shown on screen.
+func (e *Engine) drawTexts() {
+	clear(e.textLayer.Pix)
+	for _, t := range e.texts {
+		charset := e.loadCharset(t.charset)
+		if charset == nil {
+			continue
+		}
+		cs := *charset
+		cs.ColorMap[0] = byte(t.Color)
+		loc := image.Pt(t.X, t.Y)
+		for i := 0; i < len(t.String); i++ {
+			loc.X += cs.PrintChar(rune(t.String[i]), e.textLayer, loc)
+		}
+	}
+}
+
+// wrapLines breaks the given lines so they fit between the position of the style and its right
+// limit. Centered lines fit around the position instead.
+func (e *Engine) wrapLines(style TextStyle, lines []string) []string {
+	maxWidth := style.Right - style.X - 1
+	if style.Center {
+		maxWidth = min(maxWidth, style.X) * 2
+	}
+	var wrapped []string
+	for _, line := range lines {
+		wrapped = append(wrapped, e.wrapLine(line, maxWidth)...)
+	}
+	return wrapped
+}
+
+// wrapLine breaks the line at its spaces so that every resulting line fits in the given width, if
+// possible.
+func (e *Engine) wrapLine(line string, maxWidth int) []string {
+	var lines []string
+	for {
+		if width, _ := e.textSize(line); width <= maxWidth {
+			return append(lines, line)
+		}
+		cut := -1
+		for i := 1; i < len(line); i++ {
+			if line[i] != ' ' {
+				continue
+			}
+			if width, _ := e.textSize(line[:i]); width > maxWidth && cut >= 0 {
+				break
+			}
+			cut = i
+		}
+		if cut < 0 {
+			return append(lines, line)
+		}
+		lines = append(lines, line[:cut])
+		line = line[cut+1:]
+	}
+}
+
+// expandText replaces the escape codes of the text that insert variables, verbs, names and strings
+// by their values, as seen by thread t. The escape codes that control the layout are kept, and the
+// rest are removed.
+func (e *Engine) expandText(t *Thread, text string) []byte {
+	ctx := ExecContextFrom(e, t)
+	var out []byte
+	for i := 0; i < len(text); i++ {
+		if text[i] != 0xFF || i+1 == len(text) {
+			out = append(out, text[i])
+			continue
+		}
+		i++
+		code := text[i]
+		switch code {
+		case 1, 2, 3, 8:
+			out = append(out, 0xFF, code)
+			continue
+		}
+		if i+2 >= len(text) {
+			break
+		}
+		arg := uint16(text[i+1]) | uint16(text[i+2])<<8
+		i += 2
+		switch code {
+		case 4:
+			out = strconv.AppendInt(out, int64(VarRef{VarID: arg}.Read(ctx)), 10)
+		case 5:
+			if v := e.Verb(VerbID(VarRef{VarID: arg}.Read(ctx))); v != nil {
+				out = append(out, v.Name...)
+			}
+		case 6:
+			out = append(out, e.ObjectName(ObjectID(VarRef{VarID: arg}.Read(ctx)))...)
+		case 7:
+			out = append(out, e.ReadString(StringID(arg))...)
+		}
+	}
+	return out
+}
+
+// splitMessage splits the message into lines up to the first wait or keep code. The text after a
+// wait code is returned as rest, and keep tells whether a keep code was found.
+func splitMessage(msg []byte) (lines []string, rest []byte, keep bool) {
+	var line []byte
+	for i := 0; i < len(msg); i++ {
+		if msg[i] != 0xFF || i+1 == len(msg) {
+			line = append(line, msg[i])
+			continue
+		}
+		i++
+		switch msg[i] {
+		case 1, 8:
+			lines = append(lines, string(line))
+			line = nil
+		case 2:
+			return append(lines, string(line)), nil, true
+		case 3:
+			if i+1 < len(msg) {
+				rest = msg[i+1:]
+			}
+			return append(lines, string(line)), rest, false
+		}
+	}
+	return append(lines, string(line)), nil, false
+}
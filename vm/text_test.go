@@ -0,0 +1,101 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrint(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x1A, 0x64, 0x00, 0x2A, 0x00, // 0000: VAR_100 = 42
+			0x14, 0xFE, 0x00, 0x0A, 0x00, 0x14, 0x00, 0x0F, // 0005: Print 254 POS=[10,20] text="Score \xFF\x04d\x00"
+			'S', 'c', 'o', 'r', 'e', ' ', 0xFF, 0x04, 0x64, 0x00, 0x00,
+			0x14, 0x01, 0x04, 0x0F, // 0018: Print 1 CENT text="aaaa bbbb cccc dddd eeee\xFF\x01C"
+			'a', 'a', 'a', 'a', ' ', 'b', 'b', 'b', 'b', ' ', 'c', 'c', 'c', 'c', ' ',
+			'd', 'd', 'd', 'd', ' ', 'e', 'e', 'e', 'e', 0xFF, 0x01, 'C', 0x00,
+			0x80,             // 0038: BreakHere
+			0x14, 0x01, 0x0F, // 0039: Print 1 text="D\xFF\x03E"
+			'D', 0xFF, 0x03, 'E', 0x00,
+			0xA0, // 0041: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	texts := func() []string {
+		var s []string
+		for _, t := range eng.Texts() {
+			s = append(s, t.String)
+		}
+		return s
+	}
+	assert.Equal(t, []string{"Score 42", "aaaa bbbb cccc dddd", "eeee", "C"}, texts())
+	assert.Equal(t, vm.Text{Slot: vm.TextSlotPrint, X: 10, Y: 20, Color: 15, String: "Score 42"},
+		eng.Texts()[0])
+	assert.Equal(t, vm.Text{Slot: vm.TextSlotTalk, X: 4, Y: 5, Color: 15, String: "aaaa bbbb cccc dddd"},
+		eng.Texts()[1])
+	assert.Equal(t, vm.Text{Slot: vm.TextSlotTalk, X: 64, Y: 13, Color: 15, String: "eeee"},
+		eng.Texts()[2])
+	assert.Equal(t, vm.Text{Slot: vm.TextSlotTalk, X: 76, Y: 21, Color: 15, String: "C"},
+		eng.Texts()[3])
+
+	require.NoError(t, eng.RunFrame())
+	assert.Equal(t, []string{"Score 42", "D"}, texts())
+	assert.Equal(t, 80, eng.Texts()[1].X)
+}
+
+func TestPrintWrap(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x14, 0xFC, 0x00, 0x0A, 0x00, 0x14, 0x00, 0x02, 0x64, 0x00, 0x0F, // 0000: Print 252 POS=[10,20] CLI=100 text="aaaa bbbb cccc dddd eeee"
+			'a', 'a', 'a', 'a', ' ', 'b', 'b', 'b', 'b', ' ', 'c', 'c', 'c', 'c', ' ',
+			'd', 'd', 'd', 'd', ' ', 'e', 'e', 'e', 'e', 0x00,
+			0xA0, // 0025: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	assert.Equal(t, []vm.Text{
+		{Slot: vm.TextSlotSystem, X: 10, Y: 20, Color: 15, String: "aaaa bbbb"},
+		{Slot: vm.TextSlotSystem, X: 10, Y: 28, Color: 15, String: "cccc dddd"},
+		{Slot: vm.TextSlotSystem, X: 10, Y: 36, Color: 15, String: "eeee"},
+	}, eng.Texts())
+}
+
+func TestPrintOptionOrder(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x14, 0xFE, 0x00, 0x64, 0x00, 0x14, 0x00, 0x06, 0x04, 0x0F, 'A', 0x00, // 0000: Print 254 POS=[100,20] LEFT CENT text="A"
+			0x14, 0x01, 0x07, 0x00, 0x0A, 0x00, 0x1E, 0x00, 0x0F, 'B', 0x00, // 000C: Print 1 OVER POS=[10,30] text="B"
+			0xA0, // 0017: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	assert.True(t, eng.TextStyle(vm.TextSlotPrint).Center)
+	assert.False(t, eng.TextStyle(vm.TextSlotTalk).Overhead)
+	assert.Equal(t, 30, eng.TextStyle(vm.TextSlotTalk).Y)
+}
+
+func TestPrintErase(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x14, 0x01, 0x03, 0x0A, 0x00, 0x08, 0x00, 0x0F, 'A', 0x00, // 0000: Print 1 ERA=[10,8] text="A"
+			0xA0, // 000A: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+	require.Len(t, eng.Texts(), 1)
+	assert.Equal(t, "A", eng.Texts()[0].String)
+}
@@ -1,12 +1,39 @@
 package vm
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// ThreadStatus is the execution status of a thread.
+type ThreadStatus int
+
+const (
+	// ThreadDead is the status of a thread that finished its execution.
+	ThreadDead ThreadStatus = iota
+
+	// ThreadRunning is the status of a thread that is ready to run.
+	ThreadRunning
+
+	// ThreadPaused is the status of a thread that waits for a delay to expire.
+	ThreadPaused
+)
 
 type Thread struct {
 	script  *Script
 	ip      int
 	local   []int
 	symbols *SymbolTable
+
+	// object is the object whose code the thread runs, or 0 for global and room scripts. The
+	// inventory flag is set if the code was taken from the inventory rather than the room.
+	object    ObjectID
+	inventory bool
+
+	status ThreadStatus
+	yield  bool
+	delay  int
 }
 
 func NewThread(script *Script) *Thread {
@@ -19,30 +46,153 @@ func NewThreadOn(script *Script, ip int) *Thread {
 		ip:      ip,
 		local:   make([]int, MaxLocals),
 		symbols: NewSymbolTable(),
+		status:  ThreadRunning,
+	}
+}
+
+// ScriptID returns the ID of the script executed by the thread.
+func (t *Thread) ScriptID() ScriptID {
+	return t.script.ID
+}
+
+// Status returns the execution status of the thread.
+func (t *Thread) Status() ThreadStatus {
+	return t.status
+}
+
+// Script returns the script executed by the thread.
+func (t *Thread) Script() *Script {
+	return t.script
+}
+
+// ObjectID returns the object whose code the thread runs, or 0 if it runs a script.
+func (t *Thread) ObjectID() ObjectID {
+	return t.object
+}
+
+// IP returns the index of the next instruction to execute in the code of the script.
+func (t *Thread) IP() int {
+	return t.ip
+}
+
+// Address returns the script address of the next instruction to execute, or the address past the
+// end of the script if there are no more instructions.
+func (t *Thread) Address() uint16 {
+	if t.ip < len(t.script.Frames) {
+		return t.script.Frames[t.ip].StartAddress
 	}
+	return uint16(len(t.script.Bytecode))
 }
 
 func (t *Thread) ReadLocal(idx uint16) int {
+	checkVarIndex(SymbolTypeLocal, idx, MaxLocals)
 	return t.local[idx]
 }
 
 func (t *Thread) WriteLocal(idx uint16, value int) {
+	checkVarIndex(SymbolTypeLocal, idx, MaxLocals)
 	t.local[idx] = value
 }
 
+// Jump moves the instruction pointer to the instruction at the given script address.
+func (t *Thread) Jump(addr uint16) {
+	ip := t.script.indexOf(addr)
+	if ip < 0 {
+		panic(fmt.Errorf("invalid jump to address %04X in script %d", addr, t.script.ID))
+	}
+	t.ip = ip
+}
+
+// BreakHere yields the execution of the thread until the next frame.
+func (t *Thread) BreakHere() {
+	t.yield = true
+}
+
+// Retry yields the execution of the thread until the next frame, when the current instruction is
+// executed again. It is used by the instructions that wait for a condition.
+func (t *Thread) Retry() {
+	t.ip--
+	t.yield = true
+}
+
+// Delay pauses the execution of the thread for the given number of jiffies.
+func (t *Thread) Delay(jiffies int) {
+	t.delay = jiffies
+	t.status = ThreadPaused
+	t.yield = true
+}
+
+// Stop terminates the execution of the thread.
+func (t *Thread) Stop() {
+	t.status = ThreadDead
+	t.yield = true
+}
+
+// Run executes the thread until it yields or terminates. The thread terminates when it runs past
+// the end of its script. Errors raised by the instructions are returned as execution errors, but
+// in lenient mode the instructions that are not implemented are skipped.
 func (t *Thread) Run(eng *Engine) error {
 	ctx := ExecContextFrom(eng, t)
-	for {
+	t.yield = false
+	for t.status == ThreadRunning && !t.yield {
+		if t.ip >= len(t.script.Code) {
+			t.Stop()
+			break
+		}
+		if eng.stepHook != nil {
+			eng.stepHook(t)
+		}
 		inst := t.script.Code[t.ip]
-		disp := DisplayInstruction(t.symbols, inst)
-		if exec, ok := inst.(hasExecute); ok {
-			exec.Execute(ctx)
-			fmt.Printf("%04X: %s\n", t.ip, disp)
-		} else {
-			return fmt.Errorf("instruction does not implement execute: %s", disp)
+		if eng.tracer != nil {
+			eng.tracer.Trace(InstructionEvent{
+				Script:      t.script.ID,
+				Object:      t.object,
+				Address:     t.Address(),
+				Instruction: DisplayInstruction(t.symbols, inst),
+			})
+		}
+		addr, err := t.Step(ctx)
+		if err == nil {
+			continue
 		}
-		t.ip++
+		if eng.lenient != nil && errors.Is(err, ErrNotImplemented) {
+			eng.lenient.Printf("skipping %s at %04X (%s): %v", codeName(t.script.ID, t.object), addr,
+				DisplayInstruction(t.symbols, inst), err)
+			continue
+		}
+		return eng.execError(t, addr, inst, err)
+	}
+	return nil
+}
+
+// Step executes the next instruction of the thread in the given execution context. It returns the
+// address of the instruction and the error it raised, if any.
+func (t *Thread) Step(ctx ExecutionContext) (uint16, error) {
+	addr := t.Address()
+	inst := t.script.Code[t.ip]
+	t.ip++
+	return addr, Execute(ctx, inst)
+}
+
+// Execute executes the instruction, returning the error it panics with, if any. Only the errors
+// raised on purpose by the instructions are returned. Any other panic, like a runtime error, is a
+// bug and it is propagated.
+func Execute(ctx ExecutionContext, inst Instruction) (err error) {
+	exec, ok := inst.(hasExecute)
+	if !ok {
+		return ErrNotImplemented
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(error)
+			if _, isRuntime := r.(runtime.Error); !ok || isRuntime {
+				panic(r)
+			}
+			err = e
+		}
+	}()
+	exec.Execute(ctx)
+	return nil
 }
 
 type hasExecute interface {
@@ -0,0 +1,142 @@
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Tracer receives the events of the execution of the engine.
+type Tracer interface {
+	Trace(ev TraceEvent)
+}
+
+// TraceEvent is an event of the execution of the engine.
+type TraceEvent interface {
+	// Kind returns the name of the kind of event.
+	Kind() string
+
+	// String returns a human readable description of the event.
+	String() string
+}
+
+// InstructionEvent is traced when a thread executes an instruction.
+type InstructionEvent struct {
+	Script      ScriptID `json:"script"`
+	Object      ObjectID `json:"object,omitempty"`
+	Address     uint16   `json:"address"`
+	Instruction string   `json:"instruction"`
+}
+
+func (ev InstructionEvent) Kind() string { return "instruction" }
+
+func (ev InstructionEvent) String() string {
+	if ev.Object != 0 {
+		return fmt.Sprintf("object %d %04X: %s", ev.Object, ev.Address, ev.Instruction)
+	}
+	return fmt.Sprintf("script %d %04X: %s", ev.Script, ev.Address, ev.Instruction)
+}
+
+// VarWriteEvent is traced when a word, bit or local variable is written. The index of a local
+// variable is relative to the thread of the given script.
+type VarWriteEvent struct {
+	Type   SymbolType `json:"type"`
+	Index  uint16     `json:"index"`
+	Script ScriptID   `json:"script,omitempty"`
+	Old    int        `json:"old"`
+	New    int        `json:"new"`
+}
+
+func (ev VarWriteEvent) Kind() string { return "var" }
+
+func (ev VarWriteEvent) String() string {
+	return fmt.Sprintf("%s_%d = %d (was %d)", ev.Type, ev.Index, ev.New, ev.Old)
+}
+
+// ScriptStartEvent is traced when a thread starts running a script or the code of an object.
+type ScriptStartEvent struct {
+	Slot   int      `json:"slot"`
+	Script ScriptID `json:"script"`
+	Object ObjectID `json:"object,omitempty"`
+}
+
+func (ev ScriptStartEvent) Kind() string { return "start" }
+
+func (ev ScriptStartEvent) String() string {
+	if ev.Object != 0 {
+		return fmt.Sprintf("start object %d in slot %d", ev.Object, ev.Slot)
+	}
+	return fmt.Sprintf("start script %d in slot %d", ev.Script, ev.Slot)
+}
+
+// ScriptStopEvent is traced when a thread terminates or it is stopped, freeing its slot.
+type ScriptStopEvent struct {
+	Slot   int      `json:"slot"`
+	Script ScriptID `json:"script"`
+	Object ObjectID `json:"object,omitempty"`
+}
+
+func (ev ScriptStopEvent) Kind() string { return "stop" }
+
+func (ev ScriptStopEvent) String() string {
+	if ev.Object != 0 {
+		return fmt.Sprintf("stop object %d in slot %d", ev.Object, ev.Slot)
+	}
+	return fmt.Sprintf("stop script %d in slot %d", ev.Script, ev.Slot)
+}
+
+// RoomChangeEvent is traced when the engine switches from a room to another.
+type RoomChangeEvent struct {
+	From RoomID `json:"from"`
+	To   RoomID `json:"to"`
+}
+
+func (ev RoomChangeEvent) Kind() string { return "room" }
+
+func (ev RoomChangeEvent) String() string {
+	return fmt.Sprintf("room %d -> %d", ev.From, ev.To)
+}
+
+// NopTracer is a tracer that discards all the events.
+type NopTracer struct{}
+
+func (NopTracer) Trace(ev TraceEvent) {}
+
+// TextTracer is a tracer that writes every event as a line of human readable text.
+type TextTracer struct {
+	w io.Writer
+}
+
+// NewTextTracer returns a tracer that writes the events as text lines into w.
+func NewTextTracer(w io.Writer) *TextTracer {
+	return &TextTracer{w: w}
+}
+
+func (t *TextTracer) Trace(ev TraceEvent) {
+	fmt.Fprintln(t.w, ev)
+}
+
+// JSONTracer is a tracer that writes every event as a line with a JSON object, whose event field
+// is the kind of the event and the rest are the fields of the event.
+type JSONTracer struct {
+	w io.Writer
+}
+
+// NewJSONTracer returns a tracer that writes the events as JSON lines into w.
+func NewJSONTracer(w io.Writer) *JSONTracer {
+	return &JSONTracer{w: w}
+}
+
+func (t *JSONTracer) Trace(ev TraceEvent) {
+	kind, _ := json.Marshal(ev.Kind())
+	fields, err := json.Marshal(ev)
+	if err != nil {
+		panic(fmt.Errorf("cannot encode trace event %s: %w", ev.Kind(), err))
+	}
+	line := append([]byte(`{"event":`), kind...)
+	if len(fields) > 2 {
+		line = append(line, ',')
+	}
+	line = append(line, fields[1:]...)
+	t.w.Write(append(line, '\n'))
+}
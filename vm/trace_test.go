@@ -0,0 +1,68 @@
+package vm_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingTracer struct {
+	events []vm.TraceEvent
+}
+
+func (t *recordingTracer) Trace(ev vm.TraceEvent) {
+	t.events = append(t.events, ev)
+}
+
+func TestTracer(t *testing.T) {
+	rm := gameResources{
+		scriptResources: scriptResources{
+			1: {
+				0x1A, 0x64, 0x00, 0x01, 0x00, // 0000: VAR_100 = 1
+				0x0A, 0x02, 0xFF, // 0005: StartScript 2 []
+				0x72, 0x03, // 0008: LoadRoom 3
+				0xA0, // 000A: StopObjectCode
+			},
+			2: {
+				0x1A, 0x00, 0x40, 0x05, 0x00, // 0000: LOCAL_0 = 5
+				0x80, // 0005: BreakHere
+				0xA0, // 0006: StopObjectCode
+			},
+		},
+		rooms: map[vm.RoomID]vm.Room{3: {Width: 320}},
+	}
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	tracer := new(recordingTracer)
+	eng.SetTracer(tracer)
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	assert.Equal(t, []vm.TraceEvent{
+		vm.ScriptStartEvent{Slot: 0, Script: 1},
+		vm.InstructionEvent{Script: 1, Address: 0x0000, Instruction: "MOVE    VAR_100, 1"},
+		vm.VarWriteEvent{Type: vm.SymbolTypeVar, Index: 100, Old: 0, New: 1},
+		vm.InstructionEvent{Script: 1, Address: 0x0005, Instruction: "STRSC   SCRIPT_2, "},
+		vm.ScriptStartEvent{Slot: 1, Script: 2},
+		vm.InstructionEvent{Script: 2, Address: 0x0000, Instruction: "MOVE    LOCAL_0, 5"},
+		vm.VarWriteEvent{Type: vm.SymbolTypeLocal, Index: 0, Script: 2, Old: 0, New: 5},
+		vm.InstructionEvent{Script: 2, Address: 0x0005, Instruction: "BREAK   "},
+		vm.InstructionEvent{Script: 1, Address: 0x0008, Instruction: "LDRO    ROOM_3"},
+		vm.RoomChangeEvent{From: 0, To: 3},
+		vm.InstructionEvent{Script: 1, Address: 0x000A, Instruction: "SOC     "},
+		vm.ScriptStopEvent{Slot: 0, Script: 1},
+	}, tracer.events)
+
+	var text, lines bytes.Buffer
+	for _, ev := range tracer.events[4:7] {
+		vm.NewTextTracer(&text).Trace(ev)
+		vm.NewJSONTracer(&lines).Trace(ev)
+	}
+	assert.Equal(t, "start script 2 in slot 1\n"+
+		"script 2 0000: MOVE    LOCAL_0, 5\n"+
+		"LOCAL_0 = 5 (was 0)\n", text.String())
+	assert.Equal(t, `{"event":"start","slot":1,"script":2}`+"\n"+
+		`{"event":"instruction","script":2,"address":0,"instruction":"MOVE    LOCAL_0, 5"}`+"\n"+
+		`{"event":"var","type":"LOCAL","index":0,"script":2,"old":0,"new":5}`+"\n", lines.String())
+}
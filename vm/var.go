@@ -62,16 +62,16 @@ func (r VarRef) Read(ctx ExecutionContext) int {
 	case r.IsWordVar():
 		return ctx.ReadWord(r.VarID)
 	case r.IsBitVar():
-		if ctx.ReadBit(r.VarID) {
+		if ctx.ReadBit(r.VarID & 0x7FFF) {
 			return 1
 		}
 		return 0
 	case r.IsLocalVar():
-		return ctx.ReadLocal(r.VarID)
+		return ctx.ReadLocal(r.VarID & 0x000F)
 	case r.IsIndirectWord():
-		return ctx.ReadWord(r.VarID + uint16(r.Offset))
+		return ctx.ReadWord(r.indirectIndex(ctx))
 	case r.IsIndirectDerefWord():
-		return ctx.ReadWord(r.VarID + uint16(ctx.ReadWord(r.Offset)))
+		return ctx.ReadWord(r.indirectIndex(ctx))
 	default:
 		panic("unknown variable reference")
 	}
@@ -82,18 +82,28 @@ func (r VarRef) Write(ctx ExecutionContext, value int) {
 	case r.IsWordVar():
 		ctx.WriteWord(r.VarID, value)
 	case r.IsBitVar():
-		ctx.WriteBit(r.VarID, value != 0)
+		ctx.WriteBit(r.VarID&0x7FFF, value != 0)
 	case r.IsLocalVar():
-		ctx.WriteLocal(r.VarID, value)
+		ctx.WriteLocal(r.VarID&0x000F, value)
 	case r.IsIndirectWord():
-		ctx.WriteWord(r.VarID+uint16(r.Offset), value)
+		ctx.WriteWord(r.indirectIndex(ctx), value)
 	case r.IsIndirectDerefWord():
-		ctx.WriteWord(r.VarID+uint16(ctx.ReadWord(r.Offset)), value)
+		ctx.WriteWord(r.indirectIndex(ctx), value)
 	default:
 		panic("unknown variable reference")
 	}
 }
 
+// indirectIndex returns the index of the word variable referenced indirectly by r. The offset is
+// either a constant or the value of the variable it references.
+func (r VarRef) indirectIndex(ctx ExecutionContext) uint16 {
+	offset := r.Offset & 0xFFF
+	if r.IsIndirectDerefWord() {
+		offset = uint16(VarRef{VarID: r.Offset &^ 0x2000}.Read(ctx))
+	}
+	return r.VarID&0x1FFF + offset
+}
+
 // Evaluate implements the Param interface.
 func (r VarRef) Evaluate(ctx ExecutionContext) int {
 	return r.Read(ctx)
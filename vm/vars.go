@@ -0,0 +1,60 @@
+package vm
+
+// Word variables with a well-known meaning for the engine. The indices match the ones used by
+// SCUMM v4 games.
+const (
+	VarResult              uint16 = 0
+	VarEgo                 uint16 = 1
+	VarCameraPosX          uint16 = 2
+	VarHaveMsg             uint16 = 3
+	VarRoom                uint16 = 4
+	VarOverride            uint16 = 5
+	VarMachineSpeed        uint16 = 6
+	VarMe                  uint16 = 7
+	VarNumActor            uint16 = 8
+	VarCurrentLights       uint16 = 9
+	VarCurrentDrive        uint16 = 10
+	VarTmr1                uint16 = 11
+	VarTmr2                uint16 = 12
+	VarTmr3                uint16 = 13
+	VarMusicTimer          uint16 = 14
+	VarActorRangeMin       uint16 = 15
+	VarActorRangeMax       uint16 = 16
+	VarCameraMinX          uint16 = 17
+	VarCameraMaxX          uint16 = 18
+	VarTimerNext           uint16 = 19
+	VarVirtMouseX          uint16 = 20
+	VarVirtMouseY          uint16 = 21
+	VarRoomResource        uint16 = 22
+	VarLastSound           uint16 = 23
+	VarCutsceneExitKey     uint16 = 24
+	VarTalkActor           uint16 = 25
+	VarCameraFastX         uint16 = 26
+	VarScrollScript        uint16 = 27
+	VarEntryScript         uint16 = 28
+	VarEntryScript2        uint16 = 29
+	VarExitScript          uint16 = 30
+	VarExitScript2         uint16 = 31
+	VarVerbScript          uint16 = 32
+	VarSentenceScript      uint16 = 33
+	VarInventoryScript     uint16 = 34
+	VarCutsceneStartScript uint16 = 35
+	VarCutsceneEndScript   uint16 = 36
+	VarCharInc             uint16 = 37
+	VarWalkToObj           uint16 = 38
+	VarDebugMode           uint16 = 39
+	VarHeapSpace           uint16 = 40
+	VarRestartKey          uint16 = 42
+	VarPauseKey            uint16 = 43
+	VarMouseX              uint16 = 44
+	VarMouseY              uint16 = 45
+	VarTimer               uint16 = 46
+	VarTimerTotal          uint16 = 47
+	VarSoundcard           uint16 = 48
+	VarVideoMode           uint16 = 49
+	VarMainMenuKey         uint16 = 50
+	VarFixedDisk           uint16 = 51
+	VarCursorState         uint16 = 52
+	VarUserput             uint16 = 53
+	VarTalkStringY         uint16 = 54
+)
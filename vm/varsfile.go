@@ -0,0 +1,132 @@
+package vm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// The files of variables written by SaveVars hold each variable as a 16-bit little endian value,
+// and each string as its 16-bit little endian length followed by its bytes, in the order they were
+// written. LoadVars reads them back in the same order.
+
+// OpenVarsFile opens the file of the store where the variables are written, or read from if write
+// is not set. The file opened before, if any, is closed first. A file to read that does not exist
+// is not an error, but the variables and strings read from it are left unchanged.
+func (e *Engine) OpenVarsFile(name string, write bool) error {
+	if err := e.CloseVarsFile(); err != nil {
+		return err
+	}
+	if write {
+		w, err := e.store.Create(name)
+		if err != nil {
+			return fmt.Errorf("cannot open vars file %q: %w", name, err)
+		}
+		e.varsWriter = w
+		return nil
+	}
+	r, err := e.store.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot open vars file %q: %w", name, err)
+	}
+	e.varsReader = r
+	return nil
+}
+
+// CloseVarsFile closes the file opened by OpenVarsFile, storing its content if it was written.
+func (e *Engine) CloseVarsFile() error {
+	var err error
+	if e.varsWriter != nil {
+		err = e.varsWriter.Close()
+	}
+	if e.varsReader != nil {
+		e.varsReader.Close()
+	}
+	e.varsWriter, e.varsReader = nil, nil
+	if err != nil {
+		return fmt.Errorf("cannot close vars file: %w", err)
+	}
+	return nil
+}
+
+// WriteVarsFile writes the given values of variables to the open vars file.
+func (e *Engine) WriteVarsFile(values []int) error {
+	if e.varsWriter == nil {
+		return errors.New("cannot write vars: no vars file open for writing")
+	}
+	data := make([]byte, 2*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint16(data[2*i:], uint16(v))
+	}
+	if _, err := e.varsWriter.Write(data); err != nil {
+		return fmt.Errorf("cannot write vars: %w", err)
+	}
+	return nil
+}
+
+// ReadVarsFile reads the values of n variables from the open vars file. It returns nil if the file
+// to read does not exist.
+func (e *Engine) ReadVarsFile(n int) ([]int, error) {
+	if e.varsReader == nil {
+		return nil, nil
+	}
+	data := make([]byte, 2*n)
+	if _, err := io.ReadFull(e.varsReader, data); err != nil {
+		return nil, fmt.Errorf("cannot read vars: %w", err)
+	}
+	values := make([]int, n)
+	for i := range values {
+		values[i] = int(int16(binary.LittleEndian.Uint16(data[2*i:])))
+	}
+	return values, nil
+}
+
+// WriteStringsFile writes the string slots from first to last, both included, to the open vars
+// file.
+func (e *Engine) WriteStringsFile(first, last StringID) error {
+	if e.varsWriter == nil {
+		return errors.New("cannot write strings: no vars file open for writing")
+	}
+	for id := first; id <= last; id++ {
+		if err := e.checkString(id); err != nil {
+			return err
+		}
+		str := e.strings[id]
+		data := binary.LittleEndian.AppendUint16(nil, uint16(len(str)))
+		if _, err := e.varsWriter.Write(append(data, str...)); err != nil {
+			return fmt.Errorf("cannot write strings: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReadStringsFile reads the string slots from first to last, both included, from the open vars
+// file. The slots are freed if the strings read are empty.
+func (e *Engine) ReadStringsFile(first, last StringID) error {
+	if e.varsReader == nil {
+		return nil
+	}
+	for id := first; id <= last; id++ {
+		if err := e.checkString(id); err != nil {
+			return err
+		}
+		var size uint16
+		if err := binary.Read(e.varsReader, binary.LittleEndian, &size); err != nil {
+			return fmt.Errorf("cannot read strings: %w", err)
+		}
+		str := make([]byte, size)
+		if _, err := io.ReadFull(e.varsReader, str); err != nil {
+			return fmt.Errorf("cannot read strings: %w", err)
+		}
+		e.strings[id] = nil
+		if size > 0 {
+			e.strings[id] = str
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoadVars(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x1A, 0x64, 0x00, 0x2A, 0x00, // 0000: VAR_100 = 42
+			0x1A, 0x65, 0x00, 0xF9, 0xFF, // 0005: VAR_101 = -7
+			0xA7, 0x01, // 000A: SaveVars
+			0x03, 'i', 'q', 0x00, //       OPEN(iq)
+			0x01, 0x64, 0x00, 0x65, 0x00, //       VARS=[VAR_100, VAR_101]
+			0x02, 0x01, 0x02, //       STRINGS=[1, 2]
+			0x1F, //       CLOSE
+			0xA0, // 0019: StopObjectCode
+		},
+		2: {
+			0xA7, 0x02, // 0000: LoadVars
+			0x03, 'i', 'q', 0x00, //       OPEN(iq)
+			0x01, 0x64, 0x00, 0x65, 0x00, //       VARS=[VAR_100, VAR_101]
+			0x02, 0x01, 0x02, //       STRINGS=[1, 2]
+			0x1F,       //       CLOSE
+			0xA7, 0x02, // 000F: LoadVars
+			0x03, 'n', 'o', 0x00, //       OPEN(no)
+			0x01, 0x66, 0x00, 0x66, 0x00, //       VARS=[VAR_102, VAR_102]
+			0x1F, //       CLOSE
+			0xA0, // 001C: StopObjectCode
+		},
+	}
+	store := vm.NewMemStore()
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	eng.SetStore(store)
+	require.NoError(t, eng.LoadString(1, "abc"))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	data, ok := store.File("iq")
+	require.True(t, ok)
+	assert.Equal(t, []byte{0x2A, 0x00, 0xF9, 0xFF, 0x04, 0x00, 'a', 'b', 'c', 0x00, 0x00, 0x00}, data)
+
+	eng = vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	eng.SetStore(store)
+	eng.WriteWord(102, 5)
+	require.NoError(t, eng.LoadString(2, "xyz"))
+	require.NoError(t, eng.StartScript(2, nil, false))
+	assert.Equal(t, 42, eng.ReadWord(100))
+	assert.Equal(t, -7, eng.ReadWord(101))
+	assert.Equal(t, 5, eng.ReadWord(102))
+	assert.Equal(t, "abc", eng.ReadString(1))
+	assert.Equal(t, "", eng.ReadString(2))
+}
+
+func TestSaveLoadVarsRanges(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x1A, 0x64, 0x00, 0x01, 0x00, // 0000: VAR_100 = 1
+			0x1A, 0x69, 0x00, 0x02, 0x00, // 0005: VAR_105 = 2
+			0xA7, 0x01, // 000A: SaveVars
+			0x03, 'r', 0x00, //       OPEN(r)
+			0x01, 0x64, 0x00, 0x64, 0x00, //       VARS=[VAR_100, VAR_100]
+			0x01, 0x69, 0x00, 0x69, 0x00, //       VARS=[VAR_105, VAR_105]
+			0x1F, //       CLOSE
+			0xA0, // 001B: StopObjectCode
+		},
+		2: {
+			0xA7, 0x02, // 0000: LoadVars
+			0x03, 'r', 0x00, //       OPEN(r)
+			0x01, 0x69, 0x00, 0x69, 0x00, //       VARS=[VAR_105, VAR_105]
+			0x01, 0x64, 0x00, 0x64, 0x00, //       VARS=[VAR_100, VAR_100]
+			0x1F, //       CLOSE
+			0xA0, // 0010: StopObjectCode
+		},
+	}
+	store := vm.NewMemStore()
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	eng.SetStore(store)
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	data, ok := store.File("r")
+	require.True(t, ok)
+	assert.Equal(t, []byte{0x01, 0x00, 0x02, 0x00}, data)
+
+	eng = vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	eng.SetStore(store)
+	require.NoError(t, eng.StartScript(2, nil, false))
+	assert.Equal(t, 2, eng.ReadWord(100))
+	assert.Equal(t, 1, eng.ReadWord(105))
+}
@@ -0,0 +1,209 @@
+package vm
+
+// VerbID is the ID of a verb.
+type VerbID int
+
+// MaxVerbs is the number of slots of the verb table. Slot 0 is never used.
+const MaxVerbs = 100
+
+// VerbMode is the display mode of a verb.
+type VerbMode int
+
+const (
+	// VerbModeOff is the mode of verbs that are not shown.
+	VerbModeOff VerbMode = iota
+
+	// VerbModeOn is the mode of verbs that are shown and can be clicked.
+	VerbModeOn
+
+	// VerbModeDim is the mode of verbs that are shown dimmed and cannot be clicked.
+	VerbModeDim
+)
+
+// Verb is a slot of the verb table.
+type Verb struct {
+	// ID is the ID of the verb, or 0 if the slot is free.
+	ID VerbID
+
+	// Name is the text of the verb. Verbs with image have no name.
+	Name string
+
+	// ImageObject and ImageRoom are the object whose image is used to draw the verb and the room
+	// where it is, or 0 for text verbs.
+	ImageObject ObjectID
+	ImageRoom   RoomID
+
+	Color     int
+	HiColor   int
+	DimColor  int
+	BackColor int
+
+	// X and Y are the position of the verb. If the verb is centered, X is its horizontal center.
+	X      int
+	Y      int
+	Center bool
+
+	// Width and Height are the size of the verb on screen.
+	Width  int
+	Height int
+
+	Mode VerbMode
+	Key  int
+
+	// SaveID is the group the verb was saved into, or 0 if the verb is active.
+	SaveID int
+}
+
+// Visible returns true if the verb is active and shown.
+func (v *Verb) Visible() bool {
+	return v.ID != 0 && v.SaveID == 0 && v.Mode != VerbModeOff
+}
+
+// Bounds returns the screen area covered by the verb as its left, top, right and bottom limits.
+// The right and bottom limits are excluded.
+func (v *Verb) Bounds() (left, top, right, bottom int) {
+	left = v.X
+	if v.Center {
+		left -= v.Width / 2
+	}
+	return left, v.Y, left + v.Width, v.Y + v.Height
+}
+
+// reset sets the verb to the defaults of a new verb with the given ID.
+func (v *Verb) reset(id VerbID) {
+	*v = Verb{ID: id, Color: 2, DimColor: 8}
+}
+
+// Verb returns the active verb with the given ID, or nil if there is none.
+func (e *Engine) Verb(id VerbID) *Verb {
+	if slot := e.verbSlot(id, 0); slot > 0 {
+		return &e.verbs[slot]
+	}
+	return nil
+}
+
+// NewVerb allocates a slot for the verb with the given ID, reusing the slot of the active verb
+// with the same ID if any, and resets it to the defaults. It returns nil if the verb table is
+// full.
+func (e *Engine) NewVerb(id VerbID) *Verb {
+	slot := e.verbSlot(id, 0)
+	if slot == 0 {
+		slot = e.verbSlot(0, 0)
+	}
+	if slot == 0 {
+		return nil
+	}
+	v := &e.verbs[slot]
+	v.reset(id)
+	return v
+}
+
+// DeleteVerb removes the active verb with the given ID from the verb table.
+func (e *Engine) DeleteVerb(id VerbID) {
+	if slot := e.verbSlot(id, 0); slot > 0 {
+		e.verbs[slot] = Verb{}
+	}
+}
+
+// LayoutVerb updates the size of the verb after changing its name or image.
+func (e *Engine) LayoutVerb(v *Verb) {
+	if v.ImageObject != 0 {
+		v.Width, v.Height = 0, 0
+		if e.room != nil {
+			if obj := e.room.Object(v.ImageObject); obj != nil {
+				v.Width, v.Height = obj.Width, obj.Height
+			}
+		}
+		return
+	}
+	v.Width, v.Height = e.textSize(v.Name)
+}
+
+// SaveVerbs moves the active verbs with IDs in the range [start, end] into the given group, so they
+// are neither shown nor clickable until restored.
+func (e *Engine) SaveVerbs(start, end VerbID, group int) {
+	for id := start; id <= end; id++ {
+		if slot := e.verbSlot(id, 0); slot > 0 {
+			e.verbs[slot].SaveID = group
+		}
+	}
+}
+
+// RestoreVerbs brings back the verbs with IDs in the range [start, end] saved into the given group,
+// replacing the active verbs with the same IDs.
+func (e *Engine) RestoreVerbs(start, end VerbID, group int) {
+	for id := start; id <= end; id++ {
+		slot := e.verbSlot(id, group)
+		if slot == 0 {
+			continue
+		}
+		if active := e.verbSlot(id, 0); active > 0 {
+			e.verbs[active] = Verb{}
+		}
+		e.verbs[slot].SaveID = 0
+	}
+}
+
+// DeleteVerbs removes the verbs with IDs in the range [start, end] saved into the given group.
+func (e *Engine) DeleteVerbs(start, end VerbID, group int) {
+	for id := start; id <= end; id++ {
+		if slot := e.verbSlot(id, group); slot > 0 {
+			e.verbs[slot] = Verb{}
+		}
+	}
+}
+
+// Verbs returns the verbs that are shown on screen, in slot order.
+func (e *Engine) Verbs() []*Verb {
+	var verbs []*Verb
+	for i := 1; i < MaxVerbs; i++ {
+		if v := &e.verbs[i]; v.Visible() {
+			verbs = append(verbs, v)
+		}
+	}
+	return verbs
+}
+
+// VerbAt returns the clickable verb at the given screen position, or nil if there is none.
+func (e *Engine) VerbAt(x, y int) *Verb {
+	for i := MaxVerbs - 1; i > 0; i-- {
+		v := &e.verbs[i]
+		if !v.Visible() || v.Mode != VerbModeOn {
+			continue
+		}
+		if left, top, right, bottom := v.Bounds(); x >= left && x < right && y >= top && y < bottom {
+			return v
+		}
+	}
+	return nil
+}
+
+// VerbForKey returns the clickable verb bound to the given key, or nil if there is none.
+func (e *Engine) VerbForKey(key int) *Verb {
+	for i := 1; i < MaxVerbs; i++ {
+		if v := &e.verbs[i]; v.Visible() && v.Mode == VerbModeOn && key != 0 && v.Key == key {
+			return v
+		}
+	}
+	return nil
+}
+
+// verbSlot returns the slot of the verb with the given ID saved into the given group, 0 being the
+// group of active verbs, or 0 if there is none.
+func (e *Engine) verbSlot(id VerbID, group int) int {
+	for i := 1; i < MaxVerbs; i++ {
+		if e.verbs[i].ID == id && e.verbs[i].SaveID == group {
+			return i
+		}
+	}
+	return 0
+}
+
+// textSize returns the size of the given text on screen. If the current charset is not available,
+// the characters are assumed to be 8x8 pixels.
+func (e *Engine) textSize(text string) (width, height int) {
+	if cs := e.Charset(); cs != nil {
+		return cs.StringWidth(text), int(cs.FontHeight)
+	}
+	return 8 * len(text), 8
+}
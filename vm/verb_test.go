@@ -0,0 +1,40 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerbs(t *testing.T) {
+	rm := scriptResources{
+		1: {
+			0x7A, 0x05, 0x09, 0x02, 'O', 'p', 'e', 'n', 0x00, 0x05, 0x10, 0x00, 0xA0, 0x00, 0x06, 0x12, 'o', 0xFF, // 0000: Verb 5 NEW NAME="Open" AT=[16,160] ON KEY=111
+			0x80,                         // 0012: BreakHere
+			0xAB, 0x01, 0x01, 0x0A, 0x01, // 0013: SaveVerbs 1 10 1
+			0x80,                         // 0018: BreakHere
+			0xAB, 0x02, 0x01, 0x0A, 0x01, // 0019: RestoreVerbs 1 10 1
+			0xA0, // 001E: StopObjectCode
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	v := eng.VerbAt(20, 164)
+	require.NotNil(t, v)
+	assert.Equal(t, vm.VerbID(5), v.ID)
+	assert.Equal(t, "Open", v.Name)
+	assert.Nil(t, eng.VerbAt(60, 164))
+	assert.Equal(t, v, eng.VerbForKey('o'))
+	assert.Len(t, eng.Verbs(), 1)
+
+	require.NoError(t, eng.RunFrame())
+	assert.Nil(t, eng.VerbAt(20, 164))
+	assert.Nil(t, eng.Verb(5))
+
+	require.NoError(t, eng.RunFrame())
+	assert.Equal(t, v, eng.VerbAt(20, 164))
+}
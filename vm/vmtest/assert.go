@@ -0,0 +1,44 @@
+package vmtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertWords asserts that the given word variables have the given values.
+func AssertWords(t testing.TB, c *Context, want map[uint16]int) bool {
+	t.Helper()
+	got := make(map[uint16]int, len(want))
+	for idx := range want {
+		got[idx] = c.Words[idx]
+	}
+	return assert.Equal(t, want, got, "word variables")
+}
+
+// AssertBits asserts that the given bit variables have the given values.
+func AssertBits(t testing.TB, c *Context, want map[uint16]bool) bool {
+	t.Helper()
+	got := make(map[uint16]bool, len(want))
+	for idx := range want {
+		got[idx] = c.Bits[idx]
+	}
+	return assert.Equal(t, want, got, "bit variables")
+}
+
+// AssertLocals asserts that the given local variables have the given values.
+func AssertLocals(t testing.TB, c *Context, want map[uint16]int) bool {
+	t.Helper()
+	got := make(map[uint16]int, len(want))
+	for idx := range want {
+		got[idx] = c.Locals[idx]
+	}
+	return assert.Equal(t, want, got, "local variables")
+}
+
+// AssertAccesses asserts that the accesses to variables and properties are the given ones, in the
+// same order.
+func AssertAccesses(t testing.TB, c *Context, want ...Access) bool {
+	t.Helper()
+	return assert.Equal(t, want, c.Accesses, "accesses")
+}
@@ -0,0 +1,69 @@
+package vmtest
+
+// Bytecode is a builder of bytecode snippets.
+type Bytecode struct {
+	buf []byte
+}
+
+// NewBytecode returns an empty bytecode builder.
+func NewBytecode() *Bytecode {
+	return new(Bytecode)
+}
+
+// Op appends an opcode followed by the given bytes.
+func (b *Bytecode) Op(opcode byte, bytes ...byte) *Bytecode {
+	b.buf = append(append(b.buf, opcode), bytes...)
+	return b
+}
+
+// Byte appends the given bytes.
+func (b *Bytecode) Byte(bytes ...byte) *Bytecode {
+	b.buf = append(b.buf, bytes...)
+	return b
+}
+
+// Word appends the given values as little-endian words. Negative values are encoded in two's
+// complement.
+func (b *Bytecode) Word(values ...int) *Bytecode {
+	for _, v := range values {
+		b.buf = append(b.buf, byte(v), byte(v>>8))
+	}
+	return b
+}
+
+// Var appends a reference to the given word variable.
+func (b *Bytecode) Var(idx uint16) *Bytecode {
+	return b.Word(int(idx))
+}
+
+// Bit appends a reference to the given bit variable.
+func (b *Bytecode) Bit(idx uint16) *Bytecode {
+	return b.Word(int(0x8000 | idx))
+}
+
+// Local appends a reference to the given local variable.
+func (b *Bytecode) Local(idx uint16) *Bytecode {
+	return b.Word(int(0x4000 | idx))
+}
+
+// String appends the text followed by a null terminator.
+func (b *Bytecode) String(text string) *Bytecode {
+	b.buf = append(append(b.buf, text...), 0x00)
+	return b
+}
+
+// JumpTo appends the relative offset of a jump to the given address, as found at the end of the
+// jump and branch instructions.
+func (b *Bytecode) JumpTo(addr uint16) *Bytecode {
+	return b.Word(int(addr) - len(b.buf) - 2)
+}
+
+// Addr returns the address of the next byte to append, to be used as a jump target.
+func (b *Bytecode) Addr() uint16 {
+	return uint16(len(b.buf))
+}
+
+// Bytes returns the bytecode built so far.
+func (b *Bytecode) Bytes() []byte {
+	return b.buf
+}
@@ -0,0 +1,180 @@
+// Package vmtest provides utilities to test the execution of instructions without an engine.
+package vmtest
+
+import (
+	"fmt"
+
+	"github.com/apoloval/scumm-go/vm"
+)
+
+// Access is a read or write access to a variable or a property.
+type Access struct {
+	Write bool
+
+	// Name is the name of the variable, like VAR_12, BIT_3 or LOCAL_0, or the property.
+	Name  string
+	Value int
+}
+
+// Read returns a read access to the given variable or property.
+func Read(name string, value int) Access {
+	return Access{Name: name, Value: value}
+}
+
+// Write returns a write access to the given variable or property.
+func Write(name string, value int) Access {
+	return Access{Write: true, Name: name, Value: value}
+}
+
+func (a Access) String() string {
+	if a.Write {
+		return fmt.Sprintf("%s <- %d", a.Name, a.Value)
+	}
+	return fmt.Sprintf("%s -> %d", a.Name, a.Value)
+}
+
+// StartedScript is a script started by the executed code.
+type StartedScript struct {
+	ID        vm.ScriptID
+	Args      []int
+	Recursive bool
+}
+
+// Context is a fake execution context that keeps the variables and properties in memory and
+// records every access to them. It also records the control flow requested by the instructions
+// and the scripts they start.
+//
+// The rest of the methods of the execution context are forwarded to the embedded one, which panics
+// if nil. Tests of instructions that need them can set it, or embed Context into a type that
+// overrides them.
+type Context struct {
+	vm.ExecutionContext
+
+	Props  map[vm.Property]int
+	Words  [vm.MaxWords]int
+	Bits   [vm.MaxBits]bool
+	Locals [vm.MaxLocals]int
+
+	// Accesses are the accesses to variables and properties, in order.
+	Accesses []Access
+
+	// Started are the scripts started by the executed code, in order.
+	Started []StartedScript
+
+	// Yielded is set when the code yields the execution until the next frame, and Retried when the
+	// current instruction is to be executed again.
+	Yielded bool
+	Retried bool
+
+	// Delayed is the number of jiffies the code asked to pause its execution.
+	Delayed int
+
+	// Stopped is set when the code terminates its execution.
+	Stopped bool
+
+	// thread is the thread running the code, if run by Run.
+	thread *vm.Thread
+}
+
+// NewContext returns a new fake execution context with all the variables set to zero.
+func NewContext() *Context {
+	return &Context{Props: make(map[vm.Property]int)}
+}
+
+func (c *Context) GetProperty(prop vm.Property) int {
+	c.Accesses = append(c.Accesses, Read(string(prop), c.Props[prop]))
+	return c.Props[prop]
+}
+
+func (c *Context) SetProperty(prop vm.Property, value int) {
+	c.Accesses = append(c.Accesses, Write(string(prop), value))
+	c.Props[prop] = value
+}
+
+func (c *Context) ReadWord(idx uint16) int {
+	c.Accesses = append(c.Accesses, Read(wordName(idx), c.Words[idx]))
+	return c.Words[idx]
+}
+
+func (c *Context) WriteWord(idx uint16, value int) {
+	c.Accesses = append(c.Accesses, Write(wordName(idx), value))
+	c.Words[idx] = value
+}
+
+func (c *Context) ReadBit(idx uint16) bool {
+	c.Accesses = append(c.Accesses, Read(bitName(idx), boolToInt(c.Bits[idx])))
+	return c.Bits[idx]
+}
+
+func (c *Context) WriteBit(idx uint16, value bool) {
+	c.Accesses = append(c.Accesses, Write(bitName(idx), boolToInt(value)))
+	c.Bits[idx] = value
+}
+
+func (c *Context) ReadLocal(idx uint16) int {
+	c.Accesses = append(c.Accesses, Read(localName(idx), c.Locals[idx]))
+	return c.Locals[idx]
+}
+
+func (c *Context) WriteLocal(idx uint16, value int) {
+	c.Accesses = append(c.Accesses, Write(localName(idx), value))
+	c.Locals[idx] = value
+}
+
+func (c *Context) Jump(addr uint16) {
+	if c.thread != nil {
+		c.thread.Jump(addr)
+	}
+}
+
+func (c *Context) BreakHere() {
+	c.Yielded = true
+}
+
+func (c *Context) Retry() {
+	if c.thread != nil {
+		c.thread.Retry()
+	}
+	c.Retried = true
+	c.Yielded = true
+}
+
+func (c *Context) Delay(jiffies int) {
+	c.Delayed = jiffies
+	c.Yielded = true
+}
+
+func (c *Context) Stop() {
+	c.Stopped = true
+	c.Yielded = true
+}
+
+func (c *Context) StartScript(id vm.ScriptID, args []int, recursive bool) error {
+	c.Started = append(c.Started, StartedScript{ID: id, Args: args, Recursive: recursive})
+	return nil
+}
+
+// ClearAccesses forgets the recorded accesses, so the state can be prepared before executing the
+// code under test.
+func (c *Context) ClearAccesses() {
+	c.Accesses = nil
+}
+
+func wordName(idx uint16) string {
+	return fmt.Sprintf("%s_%d", vm.SymbolTypeVar, idx)
+}
+
+func bitName(idx uint16) string {
+	return fmt.Sprintf("%s_%d", vm.SymbolTypeBit, idx)
+}
+
+func localName(idx uint16) string {
+	return fmt.Sprintf("%s_%d", vm.SymbolTypeLocal, idx)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,35 @@
+package vmtest
+
+import (
+	"fmt"
+
+	"github.com/apoloval/scumm-go/vm"
+)
+
+// Run decodes the bytecode with the given decoder and executes it from the beginning until it
+// yields, terminates or runs past its end, following the jumps. It returns the address of the next
+// instruction to execute, and the error raised by the instructions, if any.
+//
+// The code runs on a thread of the vm package, so the jumps and retries work as in the engine.
+func (c *Context) Run(dec vm.InstructionDecoder, bytecode []byte) (uint16, error) {
+	script := &vm.Script{Bytecode: bytecode}
+	if err := script.Decode(dec); err != nil {
+		return 0, err
+	}
+
+	c.Yielded, c.Retried = false, false
+	c.thread = vm.NewThread(script)
+	defer func() { c.thread = nil }()
+	for !c.Yielded && c.thread.IP() < len(script.Code) {
+		if addr, err := c.thread.Step(c); err != nil {
+			return addr, fmt.Errorf("error executing %04X: %w", addr, err)
+		}
+	}
+	return c.thread.Address(), nil
+}
+
+// Exec executes a single instruction, returning the error it panics with, if any. The jumps and
+// retries it requests are only applied when run by Run.
+func (c *Context) Exec(inst vm.Instruction) error {
+	return vm.Execute(c, inst)
+}
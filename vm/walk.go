@@ -0,0 +1,225 @@
+package vm
+
+const (
+	// ActorMovingNewLeg is set while the actor is about to start a new leg of its walk.
+	ActorMovingNewLeg = 0x01
+
+	// ActorMovingInLeg is set while the actor is walking a leg.
+	ActorMovingInLeg = 0x02
+
+	// ActorMovingLastLeg is set while the actor is walking the last leg, in the destination box.
+	ActorMovingLastLeg = 0x08
+)
+
+// actorWalk is the walking state of an actor.
+type actorWalk struct {
+	// dest and destBox are the final position of the walk and the box where it is.
+	dest    Point
+	destBox int
+
+	// dir is the direction the actor faces at the end of the walk, or -1 to keep the last one.
+	dir int
+
+	// from and to are the start and end positions of the current leg, and box is the box the
+	// actor enters at the end of it.
+	from Point
+	to   Point
+	box  int
+
+	// xfrac and yfrac are the fractional parts of the actor position, and dx and dy are the 16.16
+	// fixed point increments per step.
+	xfrac int
+	yfrac int
+	dx    int
+	dy    int
+}
+
+// PutActor places the actor at the given position of its room, stopping any walk in progress.
+func (e *Engine) PutActor(a *Actor, x, y int) {
+	a.Put(x, y)
+	if a.Room != 0 && a.Room == e.roomID {
+		_, a.WalkBox = e.adjustToBoxes(Point{x, y})
+	}
+}
+
+// WalkActorTo makes the actor walk to the given position. The destination is moved into the
+// walkable boxes of the room, unless the actor ignores boxes. At the end of the walk, the actor
+// turns to dir, unless it is negative. Actors that are not in the current room are placed at the
+// destination right away.
+func (e *Engine) WalkActorTo(a *Actor, x, y int, dir int) {
+	dest, destBox := Point{x, y}, -1
+	if !a.IgnoreBoxes {
+		dest, destBox = e.adjustToBoxes(dest)
+		if b := e.box(a.WalkBox); b == nil || !b.Contains(Point{a.X, a.Y}) {
+			_, a.WalkBox = e.adjustToBoxes(Point{a.X, a.Y})
+		}
+	}
+	if a.Room == 0 || a.Room != e.roomID {
+		a.Put(dest.X, dest.Y)
+		a.WalkBox = max(destBox, 0)
+		if dir >= 0 {
+			a.Facing = dir
+		}
+		return
+	}
+	if a.X == dest.X && a.Y == dest.Y {
+		a.Moving = 0
+		if dir >= 0 {
+			a.Facing = dir
+		}
+		return
+	}
+	a.walk = actorWalk{dest: dest, destBox: destBox, dir: dir}
+	a.Moving = ActorMovingNewLeg
+}
+
+// WalkActorToObject makes the actor walk to the walking position of the given actor or object.
+func (e *Engine) WalkActorToObject(a *Actor, id int) {
+	x, y, ok := e.ObjectPosition(id)
+	if !ok {
+		return
+	}
+	dir := -1
+	if id >= MaxActors && e.room != nil {
+		if obj := e.room.Object(ObjectID(id)); obj != nil {
+			dir = oldDirToNewDir(int(obj.ActorDir))
+		}
+	}
+	e.WalkActorTo(a, x, y, dir)
+}
+
+// WalkActorToActor makes the actor walk next to another actor, keeping the given distance in the
+// horizontal axis. A distance of 0xFF means a distance based on the width of the other actor.
+func (e *Engine) WalkActorToActor(a, other *Actor, dist int) {
+	if a.Room != e.roomID || other.Room != e.roomID {
+		return
+	}
+	if dist == 0xFF {
+		dist = other.ScaleX * other.Width / 0xFF
+		dist += dist / 2
+	}
+	x, y := other.X, other.Y
+	if x < a.X {
+		x += dist
+	} else {
+		x -= dist
+	}
+	e.WalkActorTo(a, x, y, -1)
+}
+
+// walkActors advances one step the actors walking in the current room.
+func (e *Engine) walkActors() {
+	for i := 1; i < MaxActors; i++ {
+		a := &e.actors[i]
+		if a.Moving != 0 && a.Room == e.roomID {
+			e.stepActor(a)
+		}
+	}
+}
+
+func (e *Engine) stepActor(a *Actor) {
+	w := &a.walk
+	for n := 0; a.Moving&ActorMovingNewLeg != 0; n++ {
+		if n > len(e.boxMatrix) {
+			a.Moving = 0
+			return
+		}
+		e.startLeg(a)
+		if a.X != w.to.X || a.Y != w.to.Y {
+			break
+		}
+		e.endLeg(a)
+	}
+	if a.Moving == 0 {
+		return
+	}
+
+	x := (a.X << 16) + w.xfrac + (w.dx>>8)*a.ScaleX
+	y := (a.Y << 16) + w.yfrac + (w.dy>>8)*a.ScaleY
+	a.X, w.xfrac = x>>16, x&0xFFFF
+	a.Y, w.yfrac = y>>16, y&0xFFFF
+	if abs(a.X-w.from.X) >= abs(w.to.X-w.from.X) {
+		a.X = w.to.X
+	}
+	if abs(a.Y-w.from.Y) >= abs(w.to.Y-w.from.Y) {
+		a.Y = w.to.Y
+	}
+	if a.X == w.to.X && a.Y == w.to.Y {
+		e.endLeg(a)
+	}
+}
+
+// startLeg plans the next leg of the walk: straight to the destination if the actor is in the
+// destination box, or to the closest point of the next box in the route otherwise.
+func (e *Engine) startLeg(a *Actor) {
+	w := &a.walk
+	pos := Point{a.X, a.Y}
+	w.from, w.to, w.box = pos, w.dest, w.destBox
+	a.Moving = ActorMovingInLeg | ActorMovingLastLeg
+
+	if !a.IgnoreBoxes && w.destBox >= 0 && a.WalkBox != w.destBox {
+		if next := e.boxMatrix.NextBox(a.WalkBox, w.destBox); next >= 0 && next != a.WalkBox {
+			w.to, w.box = e.room.Boxes[next].ClosestPoint(pos), next
+			a.Moving = ActorMovingInLeg
+		} else if b := e.box(a.WalkBox); b != nil {
+			// The destination is not reachable: walk as close as possible within the box.
+			w.dest, w.destBox = b.ClosestPoint(w.dest), a.WalkBox
+			w.to, w.box = w.dest, a.WalkBox
+		}
+	}
+
+	diffX, diffY := w.to.X-pos.X, w.to.Y-pos.Y
+	w.dy = a.SpeedY << 16
+	if diffY < 0 {
+		w.dy = -w.dy
+	}
+	if diffY != 0 {
+		w.dx = w.dy * diffX / diffY
+	} else {
+		w.dx, w.dy = 0, 0
+	}
+	if abs(w.dx>>16) > a.SpeedX || diffY == 0 {
+		w.dx = a.SpeedX << 16
+		if diffX < 0 {
+			w.dx = -w.dx
+		}
+		if diffX != 0 {
+			w.dy = w.dx * diffY / diffX
+		} else {
+			w.dx = 0
+		}
+	}
+	w.xfrac, w.yfrac = 0, 0
+	if w.dx != 0 || w.dy != 0 {
+		a.Facing = angleFromDelta(w.dx, w.dy)
+	}
+}
+
+func (e *Engine) endLeg(a *Actor) {
+	w := &a.walk
+	if w.box >= 0 {
+		a.WalkBox = w.box
+	}
+	if a.Moving&ActorMovingLastLeg != 0 {
+		a.Moving = 0
+		if w.dir >= 0 {
+			a.Facing = w.dir
+		}
+		return
+	}
+	a.Moving = ActorMovingNewLeg
+}
+
+// angleFromDelta returns the facing direction, in degrees, of an actor moving by the given deltas.
+func angleFromDelta(dx, dy int) int {
+	if abs(dy)*2 < abs(dx) {
+		if dx > 0 {
+			return 90
+		}
+		return 270
+	}
+	if dy > 0 {
+		return 180
+	}
+	return 0
+}
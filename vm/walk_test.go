@@ -0,0 +1,61 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func box(x1, y1, x2, y2 int) vm.Box {
+	return vm.Box{
+		UL: vm.Point{X: x1, Y: y1},
+		UR: vm.Point{X: x2, Y: y1},
+		LR: vm.Point{X: x2, Y: y2},
+		LL: vm.Point{X: x1, Y: y2},
+	}
+}
+
+func TestWalkActorThroughBoxes(t *testing.T) {
+	rm := gameResources{
+		scriptResources: scriptResources{
+			1: {
+				0x72, 0x0A, // 0000: LoadRoom 10
+				0x2D, 0x01, 0x0A, // 0002: PutActorInRoom 1 10
+				0x01, 0x01, 0x14, 0x00, 0x78, 0x00, // 0005: ActorPut 1 20 120
+				0x1E, 0x01, 0x78, 0x00, 0x00, 0x00, // 000B: WalkActorTo 1 120 0
+				0xAE, 0x01, 0x01, // 0011: WaitForActor 1
+				0x1A, 0x64, 0x00, 0x01, 0x00, // 0014: VAR_100 = 1
+				0xA0, // 0019: StopObjectCode
+			},
+		},
+		rooms: map[vm.RoomID]vm.Room{
+			10: {
+				Boxes: []vm.Box{
+					box(0, 100, 100, 140),
+					box(100, 100, 200, 140),
+					box(100, 40, 140, 100),
+				},
+			},
+		},
+	}
+
+	eng := vm.NewEngine(rm, vm.NewHeadless(new(vm.FakeClock)))
+	require.NoError(t, eng.StartScript(1, nil, false))
+
+	a := eng.Actor(1)
+	assert.Equal(t, 0, a.WalkBox)
+	assert.NotZero(t, a.Moving)
+
+	for i := 0; i < 100 && eng.IsScriptRunning(1); i++ {
+		require.NoError(t, eng.RunFrame())
+		assert.True(t, a.Y >= 100 || a.X >= 100, "actor out of boxes at %d,%d", a.X, a.Y)
+	}
+	assert.False(t, eng.IsScriptRunning(1))
+	assert.Equal(t, 1, eng.ReadWord(100))
+	assert.Equal(t, 120, a.X)
+	assert.Equal(t, 40, a.Y)
+	assert.Equal(t, 2, a.WalkBox)
+	assert.Equal(t, 0, a.Moving)
+}
@@ -0,0 +1,275 @@
+package vm4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+
+	"github.com/apoloval/scumm-go/vm"
+)
+
+// StripWidth is the width of the vertical strips bitmaps are encoded in.
+const StripWidth = 8
+
+// DecodeBitmap decodes a bitmap of the given size, as found in the BM chunk of a room and in the OI
+// chunks of its objects after the object ID. The bitmap starts with its length and the offsets of
+// its strips, and it is followed by the z-planes of the image. The pixels of the strips encoded with
+// transparency that are not drawn are left as vm.TransparentColor.
+func DecodeBitmap(data []byte, width, height int) (*image.Paletted, []*image.Alpha, error) {
+	strips := width / StripWidth
+	if len(data) < 4 {
+		return nil, nil, fmt.Errorf("invalid bitmap: too short (%d bytes)", len(data))
+	}
+	smapLen := int(binary.LittleEndian.Uint32(data))
+	if smapLen > len(data) {
+		return nil, nil, fmt.Errorf("invalid bitmap: length %d exceeds %d bytes", smapLen, len(data))
+	}
+
+	// The table of strip offsets starts right after the length, or 4 bytes later in some games. The
+	// first offset points right past the table, which tells where the table starts.
+	table := -1
+	for _, at := range []int{4, 8} {
+		if at+4*strips <= smapLen && int(binary.LittleEndian.Uint32(data[at:])) == at+4*strips {
+			table = at
+			break
+		}
+	}
+	if table < 0 {
+		return nil, nil, fmt.Errorf("invalid bitmap: strip offsets table not found")
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, width, height), nil)
+	for i := range img.Pix {
+		img.Pix[i] = vm.TransparentColor
+	}
+	for i := 0; i < strips; i++ {
+		offset := int(binary.LittleEndian.Uint32(data[table+4*i:]))
+		if offset >= smapLen {
+			return nil, nil, fmt.Errorf("invalid bitmap: strip %d offset %d out of bounds", i, offset)
+		}
+		if err := decodeStrip(data[offset:smapLen], img, i*StripWidth); err != nil {
+			return nil, nil, fmt.Errorf("invalid bitmap: strip %d: %w", i, err)
+		}
+	}
+
+	var zplanes []*image.Alpha
+	for at := smapLen; at+2 <= len(data); {
+		size := int(binary.LittleEndian.Uint16(data[at:]))
+		if size == 0 || at+size > len(data) {
+			break
+		}
+		zplane, err := decodeZPlane(data[at:at+size], width, height)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid bitmap: z-plane %d: %w", len(zplanes)+1, err)
+		}
+		zplanes = append(zplanes, zplane)
+		at += size
+	}
+	return img, zplanes, nil
+}
+
+// decodeStrip decodes the strip whose data starts with its codec into the image, with the strip
+// starting at column x.
+func decodeStrip(data []byte, img *image.Paletted, x int) error {
+	if len(data) == 0 {
+		return fmt.Errorf("missing codec")
+	}
+	codec := data[0]
+	r := &stripReader{data: data[1:]}
+	height := img.Rect.Dy()
+	shift := uint(codec % 10)
+	switch {
+	case codec == 1 || codec == 8:
+		r.raw(img, x, height, codec == 8)
+	case codec >= 14 && codec <= 18:
+		r.basic(img, x, height, shift, true, false)
+	case codec >= 24 && codec <= 28:
+		r.basic(img, x, height, shift, false, false)
+	case codec >= 34 && codec <= 38:
+		r.basic(img, x, height, shift, true, true)
+	case codec >= 44 && codec <= 48:
+		r.basic(img, x, height, shift, false, true)
+	case codec >= 64 && codec <= 68, codec >= 104 && codec <= 108:
+		r.complex(img, x, height, shift, false)
+	case codec >= 84 && codec <= 88, codec >= 124 && codec <= 128:
+		r.complex(img, x, height, shift, true)
+	default:
+		return fmt.Errorf("unsupported codec %d", codec)
+	}
+	return nil
+}
+
+// stripReader reads the bits of the encoded data of a strip, least significant first. The bits are
+// read ahead, so the reader may go past the end of the data. The missing bytes are read as 0.
+type stripReader struct {
+	data    []byte
+	pos     int
+	bits    uint
+	count   uint
+	overrun bool
+}
+
+func (r *stripReader) byte() byte {
+	if r.pos >= len(r.data) {
+		r.overrun = true
+		return 0
+	}
+	r.pos++
+	return r.data[r.pos-1]
+}
+
+// fill ensures there are more than 8 bits available.
+func (r *stripReader) fill() {
+	if r.count <= 8 {
+		r.bits |= uint(r.byte()) << r.count
+		r.count += 8
+	}
+}
+
+func (r *stripReader) bit() bool {
+	r.count--
+	bit := r.bits&1 != 0
+	r.bits >>= 1
+	return bit
+}
+
+func (r *stripReader) take(n uint) byte {
+	v := byte(r.bits & (1<<n - 1))
+	r.bits >>= n
+	r.count -= n
+	return v
+}
+
+// start reads the initial color and the first byte of bits of the encoded strip.
+func (r *stripReader) start() byte {
+	color := r.byte()
+	r.bits, r.count = uint(r.byte()), 8
+	return color
+}
+
+// raw reads a strip encoded as its plain pixels, row by row.
+func (r *stripReader) raw(img *image.Paletted, x, height int, transparent bool) {
+	for y := 0; y < height; y++ {
+		for i := 0; i < StripWidth; i++ {
+			if c := r.byte(); !transparent || c != vm.TransparentColor {
+				img.SetColorIndex(x+i, y, c)
+			}
+		}
+	}
+}
+
+// basic reads a strip where each pixel either repeats the color of the previous one, sets a new
+// color of the given number of bits, or shifts the color by one.
+func (r *stripReader) basic(img *image.Paletted, x, height int, shift uint, vertical, transparent bool) {
+	color := r.start()
+	inc := byte(0xFF)
+	for n := 0; n < StripWidth*height; n++ {
+		r.fill()
+		if !transparent || color != vm.TransparentColor {
+			if vertical {
+				img.SetColorIndex(x+n/height, n%height, color)
+			} else {
+				img.SetColorIndex(x+n%StripWidth, n/StripWidth, color)
+			}
+		}
+		switch {
+		case !r.bit():
+		case !r.bit():
+			r.fill()
+			color = r.take(shift)
+			inc = 0xFF
+		case !r.bit():
+			color += inc
+		default:
+			inc = -inc
+			color += inc
+		}
+	}
+}
+
+// complex reads a strip where each pixel either repeats the color of the previous one, sets a new
+// color of the given number of bits, shifts the color by up to 4, or repeats the color a number
+// of times.
+func (r *stripReader) complex(img *image.Paletted, x, height int, shift uint, transparent bool) {
+	color := r.start()
+	total := StripWidth * height
+	n := 0
+	put := func() {
+		if !transparent || color != vm.TransparentColor {
+			img.SetColorIndex(x+n%StripWidth, n/StripWidth, color)
+		}
+		n++
+	}
+	for n < total {
+		r.fill()
+		put()
+		for r.bit() {
+			if !r.bit() {
+				r.fill()
+				color = r.take(shift)
+				break
+			}
+			if inc := int(r.take(3)) - 4; inc != 0 {
+				color += byte(inc)
+				break
+			}
+			r.fill()
+			reps := int(r.bits & 0xFF)
+			if reps == 0 {
+				reps = 256
+			}
+			for ; reps > 0; reps-- {
+				if n >= total {
+					return
+				}
+				put()
+			}
+			r.bits >>= 8
+			r.bits |= uint(r.byte()) << (r.count - 8)
+		}
+	}
+}
+
+// decodeZPlane decodes a z-plane, made of the offsets of its strips followed by their run-length
+// encoded masks. Each byte of the mask covers the 8 pixels of a row of the strip, being the most
+// significant bit the leftmost pixel. Strips with offset 0 mask nothing.
+func decodeZPlane(data []byte, width, height int) (*image.Alpha, error) {
+	zplane := image.NewAlpha(image.Rect(0, 0, width, height))
+	for i := 0; i < width/StripWidth; i++ {
+		if 2+2*i+2 > len(data) {
+			return nil, fmt.Errorf("truncated strip offsets")
+		}
+		offset := int(binary.LittleEndian.Uint16(data[2+2*i:]))
+		if offset == 0 {
+			continue
+		}
+		r := &stripReader{data: data, pos: offset}
+		for y := 0; y < height; {
+			b := r.byte()
+			run := b&0x80 != 0
+			n := int(b & 0x7F)
+			if n == 0 {
+				n = 256
+			}
+			var value byte
+			if run {
+				value = r.byte()
+			}
+			for ; n > 0 && y < height; n-- {
+				if !run {
+					value = r.byte()
+				}
+				for b := 0; b < StripWidth; b++ {
+					if value&(0x80>>b) != 0 {
+						zplane.Pix[zplane.PixOffset(i*StripWidth+b, y)] = 0xFF
+					}
+				}
+				y++
+			}
+			if r.overrun {
+				return nil, fmt.Errorf("truncated mask of strip %d", i)
+			}
+		}
+	}
+	return zplane, nil
+}
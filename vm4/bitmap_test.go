@@ -0,0 +1,41 @@
+package vm4_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBitmap(t *testing.T) {
+	data := []byte{
+		0x24, 0x00, 0x00, 0x00, // 0000: bitmap length
+		0x0C, 0x00, 0x00, 0x00, // 0004: strip 0 offset
+		0x1D, 0x00, 0x00, 0x00, // 0008: strip 1 offset
+		0x01, // 000C: strip 0, raw
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+		0x0E, 0x05, 0x1D, 0x00, 0x00, 0x00, 0x00, // 001D: strip 1, basic vertical, 4 bits per color
+		0x08, 0x00, // 0024: z-plane length
+		0x06, 0x00, 0x00, 0x00, // 0026: z-plane strip offsets
+		0x82, 0xF0, // 002A: z-plane strip 0, 2 rows of 0xF0
+	}
+
+	img, zplanes, err := vm4.DecodeBitmap(data, 16, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0x01), img.ColorIndexAt(0, 0))
+	assert.Equal(t, uint8(0x08), img.ColorIndexAt(7, 0))
+	assert.Equal(t, uint8(0x11), img.ColorIndexAt(0, 1))
+	assert.Equal(t, uint8(0x05), img.ColorIndexAt(8, 0))
+	assert.Equal(t, uint8(0x07), img.ColorIndexAt(8, 1))
+	assert.Equal(t, uint8(0x07), img.ColorIndexAt(15, 1))
+
+	require.Len(t, zplanes, 1)
+	assert.Equal(t, uint8(0xFF), zplanes[0].AlphaAt(3, 1).A)
+	assert.Equal(t, uint8(0x00), zplanes[0].AlphaAt(4, 1).A)
+	assert.Equal(t, uint8(0x00), zplanes[0].AlphaAt(8, 0).A)
+
+	_, _, err = vm4.DecodeBitmap(data[:20], 16, 2)
+	assert.Error(t, err)
+}
@@ -0,0 +1,207 @@
+package vm4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+
+	"github.com/apoloval/scumm-go/vm"
+)
+
+// DecodeCostume decodes the CO chunk of a costume, header included. The offsets in the costume are
+// relative to the beginning of the chunk.
+func DecodeCostume(id vm.CostumeID, data []byte) (*vm.Costume, error) {
+	word := func(at int) (int, error) {
+		if at < 0 || at+2 > len(data) {
+			return 0, fmt.Errorf("invalid costume %d: offset %d out of bounds", id, at)
+		}
+		return int(binary.LittleEndian.Uint16(data[at:])), nil
+	}
+	if len(data) < ChunkHeaderSize+2 {
+		return nil, fmt.Errorf("invalid costume %d: too short (%d bytes)", id, len(data))
+	}
+	numAnims := int(data[6]) + 1
+	format := data[7]
+	var numColors int
+	switch format & 0x7F {
+	case 0x58:
+		numColors = 16
+	case 0x59:
+		numColors = 32
+	default:
+		return nil, fmt.Errorf("invalid costume %d: unknown format %02X", id, format)
+	}
+	cost := &vm.Costume{
+		ID:     id,
+		Mirror: format&0x80 != 0,
+		Anims:  make([]*vm.CostumeAnim, numAnims),
+	}
+
+	// The palette is followed by the offset of the commands, the offsets of the picture tables of
+	// the limbs and the offsets of the animations.
+	at := 8
+	if at+numColors > len(data) {
+		return nil, fmt.Errorf("invalid costume %d: truncated palette", id)
+	}
+	cost.Palette = append([]byte(nil), data[at:at+numColors]...)
+	at += numColors
+	cmds, err := word(at)
+	if err != nil {
+		return nil, err
+	}
+	var limbs [vm.CostumeLimbs]int
+	for i := range limbs {
+		if limbs[i], err = word(at + 2 + 2*i); err != nil {
+			return nil, err
+		}
+	}
+	anims := at + 2 + 2*vm.CostumeLimbs
+
+	numCmds := 0
+	for i := range cost.Anims {
+		offset, err := word(anims + 2*i)
+		if err != nil {
+			return nil, err
+		}
+		if offset == 0 {
+			continue
+		}
+		anim, end, err := decodeCostumeAnim(data, offset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid costume %d: animation %d: %w", id, i, err)
+		}
+		cost.Anims[i] = anim
+		numCmds = max(numCmds, end)
+	}
+	if cmds+numCmds > len(data) {
+		return nil, fmt.Errorf("invalid costume %d: truncated commands", id)
+	}
+	cost.Commands = data[cmds : cmds+numCmds]
+
+	// Only the pictures drawn by the animations of each limb are decoded.
+	for _, anim := range cost.Anims {
+		if anim == nil {
+			continue
+		}
+		for limb, la := range anim.Limbs {
+			if la == nil || la.Hide {
+				continue
+			}
+			for pos := la.Start; pos <= la.End; pos++ {
+				cmd := cost.Commands[pos]
+				if cmd >= vm.CostumeCmdFirst || cost.Pictures[limb][cmd] != nil {
+					continue
+				}
+				offset, err := word(limbs[limb] + 2*int(cmd))
+				if err != nil {
+					return nil, err
+				}
+				pic, err := decodeCostumePicture(data, offset, numColors)
+				if err != nil {
+					return nil, fmt.Errorf(
+						"invalid costume %d: picture %d of limb %d: %w", id, cmd, limb, err)
+				}
+				if cost.Pictures[limb] == nil {
+					cost.Pictures[limb] = make(map[byte]*vm.CostumePicture)
+				}
+				cost.Pictures[limb][cmd] = pic
+			}
+		}
+	}
+	return cost, nil
+}
+
+// decodeCostumeAnim decodes the animation at the given offset. It starts with a mask of the limbs it
+// sets, being the most significant bit limb 0, followed by the command where each limb starts, or
+// 0xFFFF to hide it, and a byte with the number of commands and the no loop flag. It returns the
+// number of commands used by the animation.
+func decodeCostumeAnim(data []byte, at int) (anim *vm.CostumeAnim, cmds int, err error) {
+	next := func(n int) (int, error) {
+		if at+n > len(data) {
+			return 0, fmt.Errorf("truncated data")
+		}
+		v := int(data[at])
+		if n == 2 {
+			v = int(binary.LittleEndian.Uint16(data[at:]))
+		}
+		at += n
+		return v, nil
+	}
+	mask, err := next(2)
+	if err != nil {
+		return nil, 0, err
+	}
+	anim = new(vm.CostumeAnim)
+	for limb := 0; limb < vm.CostumeLimbs; limb++ {
+		if mask&(0x8000>>limb) == 0 {
+			continue
+		}
+		start, err := next(2)
+		if err != nil {
+			return nil, 0, err
+		}
+		if start == 0xFFFF {
+			anim.Limbs[limb] = &vm.CostumeLimbAnim{Hide: true}
+			continue
+		}
+		extra, err := next(1)
+		if err != nil {
+			return nil, 0, err
+		}
+		anim.Limbs[limb] = &vm.CostumeLimbAnim{
+			Start:  start,
+			End:    start + extra&0x7F,
+			NoLoop: extra&0x80 != 0,
+		}
+		cmds = max(cmds, start+extra&0x7F+1)
+	}
+	return anim, cmds, nil
+}
+
+// decodeCostumePicture decodes the picture at the given offset. It has a header with its size, its
+// offset and the offset it applies to the next limbs, followed by its pixels encoded column by
+// column as runs of the same color.
+func decodeCostumePicture(data []byte, at, numColors int) (*vm.CostumePicture, error) {
+	if at+12 > len(data) {
+		return nil, fmt.Errorf("truncated header")
+	}
+	field := func(i int) int { return int(int16(binary.LittleEndian.Uint16(data[at+2*i:]))) }
+	width, height := field(0), field(1)
+	if width < 0 || height < 0 {
+		return nil, fmt.Errorf("invalid size %dx%d", width, height)
+	}
+	pic := &vm.CostumePicture{
+		Image: image.NewPaletted(image.Rect(0, 0, width, height), nil),
+		RelX:  field(2),
+		RelY:  field(3),
+		MoveX: field(4),
+		MoveY: field(5),
+	}
+	shift, mask := 4, byte(0x0F)
+	if numColors == 32 {
+		shift, mask = 3, 0x07
+	}
+	at += 12
+	for n := 0; n < width*height; {
+		if at >= len(data) {
+			return nil, fmt.Errorf("truncated pixels")
+		}
+		color, reps := data[at]>>shift, int(data[at]&mask)
+		at++
+		if reps == 0 {
+			if at >= len(data) {
+				return nil, fmt.Errorf("truncated pixels")
+			}
+			reps = int(data[at])
+			at++
+		}
+		if reps == 0 {
+			reps = 256
+		}
+		for ; reps > 0 && n < width*height; reps-- {
+			pic.Image.SetColorIndex(n/height, n%height, color)
+			n++
+		}
+	}
+	return pic, nil
+}
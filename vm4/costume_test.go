@@ -0,0 +1,54 @@
+package vm4_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/apoloval/scumm-go/vm4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCostume(t *testing.T) {
+	data := []byte{
+		0x67, 0x00, 0x00, 0x00, 'C', 'O', // 0000: chunk header
+		0x03, 0x58, // 0006: 4 animations, 16 colors
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, // 0008: palette
+		0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F,
+		0x47, 0x00, // 0018: commands offset
+		0x49, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 001A: limb picture tables
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x42, 0x00, 0x00, 0x00, 0x00, 0x00, // 003A: animation offsets
+		0x00, 0x80, 0x00, 0x00, 0x01, // 0042: animation 1, limb 0 plays commands 0 to 1
+		0x00, 0x01, // 0047: commands
+		0x4D, 0x00, 0x5A, 0x00, // 0049: limb 0 pictures
+		0x02, 0x00, 0x02, 0x00, 0xFF, 0xFF, 0xFE, 0xFF, 0x00, 0x00, 0x00, 0x00, // 004D: picture 0
+		0x34,
+		0x02, 0x00, 0x02, 0x00, 0xFF, 0xFF, 0xFE, 0xFF, 0x00, 0x00, 0x00, 0x00, // 005A: picture 1
+		0x52, 0x62,
+	}
+
+	cost, err := vm4.DecodeCostume(7, data)
+	require.NoError(t, err)
+	assert.Equal(t, vm.CostumeID(7), cost.ID)
+	assert.False(t, cost.Mirror)
+	assert.Len(t, cost.Palette, 16)
+	require.Len(t, cost.Anims, 4)
+	assert.Nil(t, cost.Anims[0])
+	require.NotNil(t, cost.Anims[1])
+	assert.Equal(t, &vm.CostumeLimbAnim{Start: 0, End: 1}, cost.Anims[1].Limbs[0])
+	assert.Equal(t, []byte{0x00, 0x01}, cost.Commands)
+
+	pic := cost.Pictures[0][0]
+	require.NotNil(t, pic)
+	assert.Equal(t, -1, pic.RelX)
+	assert.Equal(t, -2, pic.RelY)
+	assert.Equal(t, uint8(3), pic.Image.ColorIndexAt(1, 1))
+
+	pic = cost.Pictures[0][1]
+	require.NotNil(t, pic)
+	assert.Equal(t, uint8(5), pic.Image.ColorIndexAt(0, 1))
+	assert.Equal(t, uint8(6), pic.Image.ColorIndexAt(1, 0))
+}
@@ -6,12 +6,22 @@ import (
 	"github.com/apoloval/scumm-go/vm"
 )
 
+// ActorOp is a sub-operation of an actor instruction, applied to the actor in script order.
+type ActorOp interface {
+	Display(st *vm.SymbolTable) string
+	apply(ctx vm.ExecutionContext, a *vm.Actor)
+}
+
 type ActorDummy struct {
 	Arg1 vm.Param `op:"p8" pos:"1" fmt:"dec"`
 }
 
 func (inst ActorDummy) Display(st *vm.SymbolTable) string { return "DUMMY" }
 
+func (inst ActorDummy) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	inst.Arg1.Evaluate(ctx)
+}
+
 type ActorCostume struct {
 	Costume vm.Param `op:"p8" pos:"1" fmt:"id:costume"`
 }
@@ -20,6 +30,10 @@ func (inst ActorCostume) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("CO=%s", inst.Costume.Display(st))
 }
 
+func (inst ActorCostume) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.Costume = vm.CostumeID(inst.Costume.Evaluate(ctx))
+}
+
 type ActorStepDist struct {
 	XSpeed vm.Param `op:"p8" pos:"1" fmt:"dec"`
 	YSpeed vm.Param `op:"p8" pos:"2" fmt:"dec"`
@@ -29,6 +43,11 @@ func (inst ActorStepDist) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("STEPDIST=%s,%s", inst.XSpeed.Display(st), inst.YSpeed.Display(st))
 }
 
+func (inst ActorStepDist) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.SpeedX = inst.XSpeed.Evaluate(ctx)
+	a.SpeedY = inst.YSpeed.Evaluate(ctx)
+}
+
 type ActorSound struct {
 	Sound vm.Param `op:"p8" pos:"1" fmt:"id:sound"`
 }
@@ -37,6 +56,10 @@ func (inst ActorSound) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("SOUND=%s", inst.Sound.Display(st))
 }
 
+func (inst ActorSound) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.Sound = vm.SoundID(inst.Sound.Evaluate(ctx))
+}
+
 type ActorWalkAnimation struct {
 	WalkFrame vm.Param `op:"p8" pos:"1" fmt:"dec"`
 }
@@ -45,6 +68,10 @@ func (inst ActorWalkAnimation) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("WALK=%s", inst.WalkFrame.Display(st))
 }
 
+func (inst ActorWalkAnimation) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.WalkFrame = inst.WalkFrame.Evaluate(ctx)
+}
+
 type ActorTalkAnimation struct {
 	StartTalk vm.Param `op:"p8" pos:"1" fmt:"dec"`
 	EndTalk   vm.Param `op:"p8" pos:"2" fmt:"dec"`
@@ -54,6 +81,11 @@ func (inst ActorTalkAnimation) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("TALK=[%s-%s]", inst.StartTalk.Display(st), inst.EndTalk.Display(st))
 }
 
+func (inst ActorTalkAnimation) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.TalkStartFrame = inst.StartTalk.Evaluate(ctx)
+	a.TalkStopFrame = inst.EndTalk.Evaluate(ctx)
+}
+
 type ActorStandAnimation struct {
 	StandFrame vm.Param `op:"p8" pos:"1" fmt:"dec"`
 }
@@ -62,6 +94,10 @@ func (inst ActorStandAnimation) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("STAND=%s", inst.StandFrame.Display(st))
 }
 
+func (inst ActorStandAnimation) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.StandFrame = inst.StandFrame.Evaluate(ctx)
+}
+
 type ActorAnimation struct {
 	Arg1 vm.Param `op:"p8" pos:"1" fmt:"dec"`
 	Arg2 vm.Param `op:"p8" pos:"2" fmt:"dec"`
@@ -73,10 +109,21 @@ func (inst ActorAnimation) Display(st *vm.SymbolTable) string {
 		inst.Arg1.Display(st), inst.Arg2.Display(st), inst.Arg3.Display(st))
 }
 
+func (inst ActorAnimation) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	// The operands are evaluated but ignored, as the original engine does.
+	inst.Arg1.Evaluate(ctx)
+	inst.Arg2.Evaluate(ctx)
+	inst.Arg3.Evaluate(ctx)
+}
+
 type ActorDefault struct{}
 
 func (inst ActorDefault) Display(st *vm.SymbolTable) string { return "DEF" }
 
+func (inst ActorDefault) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.Init(false)
+}
+
 type ActorElevation struct {
 	Elevation vm.Param `op:"p16" pos:"1" fmt:"dec"`
 }
@@ -85,10 +132,18 @@ func (inst ActorElevation) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("ELEV=%s", inst.Elevation.Display(st))
 }
 
+func (inst ActorElevation) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.Elevation = int(int16(inst.Elevation.Evaluate(ctx)))
+}
+
 type ActorAnimationDefault struct{}
 
 func (inst ActorAnimationDefault) Display(st *vm.SymbolTable) string { return "ANIMDEF" }
 
+func (inst ActorAnimationDefault) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.InitAnimations()
+}
+
 type ActorPalette struct {
 	Index vm.Param `op:"p8" pos:"1" fmt:"dec"`
 	Value vm.Param `op:"p8" pos:"2" fmt:"hex"`
@@ -98,6 +153,14 @@ func (inst ActorPalette) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("PAL[%s]=%s", inst.Index.Display(st), inst.Value.Display(st))
 }
 
+func (inst ActorPalette) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	i := inst.Index.Evaluate(ctx)
+	if i < 0 || i >= vm.ActorPaletteSize {
+		panic(fmt.Errorf("invalid palette index %d for actor %d", i, a.ID))
+	}
+	a.Palette[i] = inst.Value.Evaluate(ctx)
+}
+
 type ActorTalkColor struct {
 	Color vm.Param `op:"p8" pos:"1" fmt:"hex"`
 }
@@ -106,6 +169,10 @@ func (inst ActorTalkColor) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("TKCOL=%s", inst.Color.Display(st))
 }
 
+func (inst ActorTalkColor) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.TalkColor = inst.Color.Evaluate(ctx)
+}
+
 type ActorName struct {
 	Name string `op:"str"`
 }
@@ -114,6 +181,10 @@ func (inst ActorName) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("NAME=%q", inst.Name)
 }
 
+func (inst ActorName) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.Name = inst.Name
+}
+
 type ActorInitAnimation struct {
 	InitFrame vm.Param `op:"p8" pos:"1" fmt:"dec"`
 }
@@ -122,6 +193,10 @@ func (inst ActorInitAnimation) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("ANINIT[%s]", inst.InitFrame.Display(st))
 }
 
+func (inst ActorInitAnimation) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.InitFrame = inst.InitFrame.Evaluate(ctx)
+}
+
 type ActorWidth struct {
 	Width vm.Param `op:"p8" pos:"1" fmt:"dec"`
 }
@@ -130,6 +205,10 @@ func (inst ActorWidth) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("WIDTH=%s", inst.Width.Display(st))
 }
 
+func (inst ActorWidth) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.Width = inst.Width.Evaluate(ctx)
+}
+
 type ActorScale struct {
 	Scale vm.Param `op:"p8" pos:"1" fmt:"dec"`
 }
@@ -138,91 +217,42 @@ func (inst ActorScale) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("SCALE=%s", inst.Scale.Display(st))
 }
 
+func (inst ActorScale) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	scale := inst.Scale.Evaluate(ctx)
+	a.ScaleX = scale
+	a.ScaleY = scale
+}
+
 type ActorIgnoreBoxes struct{}
 
 func (inst ActorIgnoreBoxes) Display(st *vm.SymbolTable) string { return "IGNBOX" }
 
+func (inst ActorIgnoreBoxes) apply(ctx vm.ExecutionContext, a *vm.Actor) {
+	a.IgnoreBoxes = true
+}
+
 type Actor struct {
-	Actor            vm.Param               `op:"p8" pos:"1" fmt:"id:actor"`
-	Dummy            *ActorDummy            // 0x00
-	SetCostume       *ActorCostume          // 0x01
-	StepDist         *ActorStepDist         // 0x04
-	Sound            *ActorSound            // 0x05
-	WalkAnimation    *ActorWalkAnimation    // 0x06
-	TalkAnimation    *ActorTalkAnimation    // 0x07
-	StandAnimation   *ActorStandAnimation   // 0x08
-	Animation        *ActorAnimation        // 0x09
-	Default          *ActorDefault          // 0x0A
-	Elevation        *ActorElevation        // 0x0B
-	AnimationDefault *ActorAnimationDefault // 0x0C
-	Palette          *ActorPalette          // 0x0D
-	TalkColor        *ActorTalkColor        // 0x0E
-	ActorName        *ActorName             // 0x0F
-	InitAnimation    *ActorInitAnimation    // 0x10
-	ActorWidth       *ActorWidth            // 0x12
-	ActorScale       *ActorScale            // 0x13
-	IgnoreBoxes      *ActorIgnoreBoxes      // 0x14
+	Actor vm.Param `op:"p8" pos:"1" fmt:"id:actor"`
+
+	// Ops are the sub-operations in the order they appear in the script.
+	Ops []ActorOp
 }
 
 func (inst Actor) Acronym() string { return "ACTOR" }
 
-func (inst Actor) DisplayOperands(st *vm.SymbolTable) []string {
-	var props []string
-	if inst.Dummy != nil {
-		props = append(props, inst.Dummy.Display(st))
-	}
-	if inst.SetCostume != nil {
-		props = append(props, inst.SetCostume.Display(st))
-	}
-	if inst.StepDist != nil {
-		props = append(props, inst.StepDist.Display(st))
-	}
-	if inst.Sound != nil {
-		props = append(props, inst.Sound.Display(st))
-	}
-	if inst.WalkAnimation != nil {
-		props = append(props, inst.WalkAnimation.Display(st))
-	}
-	if inst.TalkAnimation != nil {
-		props = append(props, inst.TalkAnimation.Display(st))
-	}
-	if inst.StandAnimation != nil {
-		props = append(props, inst.StandAnimation.Display(st))
-	}
-	if inst.Animation != nil {
-		props = append(props, inst.Animation.Display(st))
-	}
-	if inst.Default != nil {
-		props = append(props, inst.Default.Display(st))
-	}
-	if inst.Elevation != nil {
-		props = append(props, inst.Elevation.Display(st))
-	}
-	if inst.AnimationDefault != nil {
-		props = append(props, inst.AnimationDefault.Display(st))
-	}
-	if inst.Palette != nil {
-		props = append(props, inst.Palette.Display(st))
-	}
-	if inst.TalkColor != nil {
-		props = append(props, inst.TalkColor.Display(st))
-	}
-	if inst.ActorName != nil {
-		props = append(props, inst.ActorName.Display(st))
-	}
-	if inst.InitAnimation != nil {
-		props = append(props, inst.InitAnimation.Display(st))
+func (inst Actor) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	for _, op := range inst.Ops {
+		op.apply(ctx, a)
 	}
-	if inst.ActorWidth != nil {
-		props = append(props, inst.ActorWidth.Display(st))
-	}
-	if inst.ActorScale != nil {
-		props = append(props, inst.ActorScale.Display(st))
-	}
-	if inst.IgnoreBoxes != nil {
-		props = append(props, inst.IgnoreBoxes.Display(st))
+}
+
+func (inst Actor) DisplayOperands(st *vm.SymbolTable) []string {
+	props := []string{inst.Actor.Display(st)}
+	for _, op := range inst.Ops {
+		props = append(props, op.Display(st))
 	}
-	return append([]string{inst.Actor.Display(st)}, props...)
+	return props
 }
 
 func (inst *Actor) DecodeOperands(opcode vm.OpCode, r *vm.BytecodeDecoder) error {
@@ -232,81 +262,83 @@ func (inst *Actor) DecodeOperands(opcode vm.OpCode, r *vm.BytecodeDecoder) error
 		if sub == 0xFF {
 			return nil
 		}
+		var op ActorOp
 		switch sub & 0x1F {
 		case 0x00:
-			inst.Dummy = &ActorDummy{
+			op = ActorDummy{
 				Arg1: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 			}
 		case 0x01:
-			inst.SetCostume = &ActorCostume{
+			op = ActorCostume{
 				Costume: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatCostumeID),
 			}
 		case 0x04:
-			inst.StepDist = &ActorStepDist{
+			op = ActorStepDist{
 				XSpeed: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 				YSpeed: r.DecodeByteParam(sub, vm.ParamPos2, vm.NumberFormatDecimal),
 			}
 		case 0x05:
-			inst.Sound = &ActorSound{
+			op = ActorSound{
 				Sound: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatSoundID),
 			}
 		case 0x06:
-			inst.WalkAnimation = &ActorWalkAnimation{
+			op = ActorWalkAnimation{
 				WalkFrame: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 			}
 		case 0x07:
-			inst.TalkAnimation = &ActorTalkAnimation{
+			op = ActorTalkAnimation{
 				StartTalk: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 				EndTalk:   r.DecodeByteParam(sub, vm.ParamPos2, vm.NumberFormatDecimal),
 			}
 		case 0x08:
-			inst.StandAnimation = &ActorStandAnimation{
+			op = ActorStandAnimation{
 				StandFrame: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 			}
 		case 0x09:
-			inst.Animation = &ActorAnimation{
+			op = ActorAnimation{
 				Arg1: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 				Arg2: r.DecodeByteParam(sub, vm.ParamPos2, vm.NumberFormatDecimal),
 				Arg3: r.DecodeByteParam(sub, vm.ParamPos3, vm.NumberFormatDecimal),
 			}
 		case 0x0A:
-			inst.Default = new(ActorDefault)
+			op = ActorDefault{}
 		case 0x0B:
-			inst.Elevation = &ActorElevation{
+			op = ActorElevation{
 				Elevation: r.DecodeWordParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 			}
 		case 0x0C:
-			inst.AnimationDefault = new(ActorAnimationDefault)
+			op = ActorAnimationDefault{}
 		case 0x0D:
-			inst.Palette = &ActorPalette{
+			op = ActorPalette{
 				Index: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 				Value: r.DecodeByteParam(sub, vm.ParamPos2, vm.NumberFormatHex),
 			}
 		case 0x0E:
-			inst.TalkColor = &ActorTalkColor{
+			op = ActorTalkColor{
 				Color: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatHex),
 			}
 		case 0x0F:
-			inst.ActorName = &ActorName{
+			op = ActorName{
 				Name: r.DecodeString(),
 			}
 		case 0x10:
-			inst.InitAnimation = &ActorInitAnimation{
+			op = ActorInitAnimation{
 				InitFrame: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 			}
 		case 0x12:
-			inst.ActorWidth = &ActorWidth{
+			op = ActorWidth{
 				Width: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 			}
 		case 0x13:
-			inst.ActorScale = &ActorScale{
+			op = ActorScale{
 				Scale: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 			}
 		case 0x14:
-			inst.IgnoreBoxes = new(ActorIgnoreBoxes)
+			op = ActorIgnoreBoxes{}
 		default:
 			return fmt.Errorf("unknown sub-opcode %02X for actor ops instructions", sub)
 		}
+		inst.Ops = append(inst.Ops, op)
 	}
 }
 
@@ -318,6 +350,16 @@ type ActorFromPos struct {
 
 func (inst ActorFromPos) Acronym() string { return "ACTORAT" }
 
+func (inst ActorFromPos) Execute(ctx vm.ExecutionContext) {
+	x := int(int16(inst.X.Evaluate(ctx)))
+	y := int(int16(inst.Y.Evaluate(ctx)))
+	var id int
+	if a := ctx.ActorAt(x, y); a != nil {
+		id = int(a.ID)
+	}
+	inst.Result.Write(ctx, id)
+}
+
 type GetActorX struct {
 	Result vm.VarRef `op:"result"`
 	Actor  vm.Param  `op:"p16" pos:"1" fmt:"id:actor"`
@@ -325,6 +367,14 @@ type GetActorX struct {
 
 func (inst GetActorX) Acronym() string { return "ACTORX" }
 
+func (inst GetActorX) Execute(ctx vm.ExecutionContext) {
+	x, _, ok := ctx.ObjectPosition(inst.Actor.Evaluate(ctx))
+	if !ok {
+		x = -1
+	}
+	inst.Result.Write(ctx, x)
+}
+
 type GetActorY struct {
 	Result vm.VarRef `op:"result"`
 	Actor  vm.Param  `op:"p16" pos:"1" fmt:"id:actor"`
@@ -332,6 +382,14 @@ type GetActorY struct {
 
 func (inst GetActorY) Acronym() string { return "ACTORY" }
 
+func (inst GetActorY) Execute(ctx vm.ExecutionContext) {
+	_, y, ok := ctx.ObjectPosition(inst.Actor.Evaluate(ctx))
+	if !ok {
+		y = -1
+	}
+	inst.Result.Write(ctx, y)
+}
+
 type GetActorWidth struct {
 	Result vm.VarRef `op:"result"`
 	Actor  vm.Param  `op:"p8" pos:"1" fmt:"id:actor"`
@@ -339,6 +397,11 @@ type GetActorWidth struct {
 
 func (inst GetActorWidth) Acronym() string { return "ACTORW" }
 
+func (inst GetActorWidth) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	inst.Result.Write(ctx, a.Width)
+}
+
 type GetActorScale struct {
 	Result vm.VarRef `op:"result"`
 	Actor  vm.Param  `op:"p8" pos:"1" fmt:"id:actor"`
@@ -346,6 +409,11 @@ type GetActorScale struct {
 
 func (inst GetActorScale) Acronym() string { return "ACTORSC" }
 
+func (inst GetActorScale) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	inst.Result.Write(ctx, a.ScaleX)
+}
+
 type GetActorWalkBox struct {
 	Result vm.VarRef `op:"result"`
 	Actor  vm.Param  `op:"p8" pos:"1" fmt:"id:actor"`
@@ -353,6 +421,11 @@ type GetActorWalkBox struct {
 
 func (inst GetActorWalkBox) Acronym() string { return "ACTORWB" }
 
+func (inst GetActorWalkBox) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	inst.Result.Write(ctx, a.WalkBox)
+}
+
 type GetActorFacing struct {
 	Result vm.VarRef `op:"result"`
 	Actor  vm.Param  `op:"p8" pos:"1" fmt:"id:actor"`
@@ -360,6 +433,11 @@ type GetActorFacing struct {
 
 func (inst GetActorFacing) Acronym() string { return "ACTORFA" }
 
+func (inst GetActorFacing) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	inst.Result.Write(ctx, a.FacingCode())
+}
+
 type GetActorElevation struct {
 	Result vm.VarRef `op:"result"`
 	Actor  vm.Param  `op:"p8" pos:"1" fmt:"id:actor"`
@@ -367,6 +445,11 @@ type GetActorElevation struct {
 
 func (inst GetActorElevation) Acronym() string { return "ACTOREL" }
 
+func (inst GetActorElevation) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	inst.Result.Write(ctx, a.Elevation)
+}
+
 type GetActorMoving struct {
 	Result vm.VarRef `op:"result"`
 	Actor  vm.Param  `op:"p8" pos:"1" fmt:"id:actor"`
@@ -374,6 +457,11 @@ type GetActorMoving struct {
 
 func (inst GetActorMoving) Acronym() string { return "ACTORMOV" }
 
+func (inst GetActorMoving) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	inst.Result.Write(ctx, a.Moving)
+}
+
 type GetActorRoom struct {
 	Result vm.VarRef `op:"result"`
 	Actor  vm.Param  `op:"p8" pos:"1" fmt:"id:actor"`
@@ -381,6 +469,11 @@ type GetActorRoom struct {
 
 func (inst GetActorRoom) Acronym() string { return "ACTRO" }
 
+func (inst GetActorRoom) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	inst.Result.Write(ctx, int(a.Room))
+}
+
 type GetActorCostume struct {
 	Result vm.VarRef `op:"result"`
 	Actor  vm.Param  `op:"p8" pos:"1" fmt:"id:actor"`
@@ -388,6 +481,11 @@ type GetActorCostume struct {
 
 func (inst GetActorCostume) Acronym() string { return "ACTORCO" }
 
+func (inst GetActorCostume) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	inst.Result.Write(ctx, int(a.Costume))
+}
+
 type GetActorClosestObject struct {
 	Result vm.VarRef `op:"result"`
 	Actor  vm.Param  `op:"p16" pos:"1" fmt:"id:actor"`
@@ -395,6 +493,10 @@ type GetActorClosestObject struct {
 
 func (inst GetActorClosestObject) Acronym() string { return "ACTORCLOBJ" }
 
+func (inst GetActorClosestObject) Execute(ctx vm.ExecutionContext) {
+	inst.Result.Write(ctx, ctx.ClosestActor(inst.Actor.Evaluate(ctx)))
+}
+
 type FaceActor struct {
 	Actor  vm.Param `op:"p8" pos:"1" fmt:"id:actor"`
 	Object vm.Param `op:"p16" pos:"2" fmt:"id:object"`
@@ -402,6 +504,11 @@ type FaceActor struct {
 
 func (inst FaceActor) Acronym() string { return "FACEA" }
 
+func (inst FaceActor) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	ctx.FaceActorTo(a, inst.Object.Evaluate(ctx))
+}
+
 type WalkActorTo struct {
 	Actor vm.Param `op:"p8" pos:"1" fmt:"id:actor"`
 	X     vm.Param `op:"p16" pos:"2" fmt:"dec"`
@@ -410,6 +517,13 @@ type WalkActorTo struct {
 
 func (inst WalkActorTo) Acronym() string { return "WALKT" }
 
+func (inst WalkActorTo) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	x := int(int16(inst.X.Evaluate(ctx)))
+	y := int(int16(inst.Y.Evaluate(ctx)))
+	ctx.WalkActorTo(a, x, y, -1)
+}
+
 type WalkActorToObject struct {
 	Actor  vm.Param `op:"p8" pos:"1" fmt:"id:actor"`
 	Object vm.Param `op:"p16" pos:"2" fmt:"id:object"`
@@ -417,6 +531,11 @@ type WalkActorToObject struct {
 
 func (inst WalkActorToObject) Acronym() string { return "WALKO" }
 
+func (inst WalkActorToObject) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	ctx.WalkActorToObject(a, inst.Object.Evaluate(ctx))
+}
+
 type WalkActorToActor struct {
 	Walker   vm.Param    `op:"p8" pos:"1" fmt:"id:actor"`
 	Walkee   vm.Param    `op:"p8" pos:"2" fmt:"id:actor"`
@@ -425,12 +544,24 @@ type WalkActorToActor struct {
 
 func (inst WalkActorToActor) Acronym() string { return "WALKA" }
 
+func (inst WalkActorToActor) Execute(ctx vm.ExecutionContext) {
+	walker := actorParam(ctx, inst.Walker)
+	walkee := actorParam(ctx, inst.Walkee)
+	ctx.WalkActorToActor(walker, walkee, inst.Distance.Evaluate(ctx))
+}
+
 type ActorFollowCamera struct {
 	Actor vm.Param `op:"p8" pos:"1" fmt:"id:actor"`
 }
 
 func (inst ActorFollowCamera) Acronym() string { return "AFC" }
 
+func (inst ActorFollowCamera) Execute(ctx vm.ExecutionContext) {
+	if err := ctx.ActorFollowCamera(actorParam(ctx, inst.Actor)); err != nil {
+		panic(err)
+	}
+}
+
 // ActorPut is a instruction to put an actor at a given position.
 type ActorPut struct {
 	Actor vm.Param `op:"p8" pos:"1" fmt:"dec"`
@@ -440,6 +571,11 @@ type ActorPut struct {
 
 func (inst ActorPut) Acronym() string { return "ACPUT" }
 
+func (inst ActorPut) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	ctx.PutActor(a, int(int16(inst.X.Evaluate(ctx))), int(int16(inst.Y.Evaluate(ctx))))
+}
+
 type PutActorInRoom struct {
 	Actor vm.Param `op:"p8" pos:"1" fmt:"id:actor"`
 	Room  vm.Param `op:"p8" pos:"2" fmt:"id:room"`
@@ -447,6 +583,14 @@ type PutActorInRoom struct {
 
 func (inst PutActorInRoom) Acronym() string { return "PAIR" }
 
+func (inst PutActorInRoom) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	a.Room = vm.RoomID(inst.Room.Evaluate(ctx))
+	if a.Room == 0 {
+		a.Put(0, 0)
+	}
+}
+
 type PutActorAtObject struct {
 	Actor  vm.Param `op:"p8" pos:"1" fmt:"id:actor"`
 	Object vm.Param `op:"p16" pos:"2" fmt:"id:object"`
@@ -454,9 +598,30 @@ type PutActorAtObject struct {
 
 func (inst PutActorAtObject) Acronym() string { return "PAO" }
 
+func (inst PutActorAtObject) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	ctx.PutActorAtObject(a, inst.Object.Evaluate(ctx))
+}
+
 type AnimateActor struct {
 	Actor     vm.Param `op:"p8" pos:"1" fmt:"id:actor"`
 	Animation vm.Param `op:"p8" pos:"2" fmt:"dec"`
 }
 
 func (inst AnimateActor) Acronym() string { return "ANIM" }
+
+func (inst AnimateActor) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	a.Animate(inst.Animation.Evaluate(ctx))
+}
+
+// actorParam evaluates the given parameter as an actor ID and returns the actor. It panics if the
+// ID is out of range.
+func actorParam(ctx vm.ExecutionContext, p vm.Param) *vm.Actor {
+	id := p.Evaluate(ctx)
+	a := ctx.Actor(vm.ActorID(id))
+	if a == nil {
+		panic(fmt.Errorf("invalid actor %d", id))
+	}
+	return a
+}
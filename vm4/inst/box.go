@@ -13,6 +13,10 @@ type SetBoxFlags struct {
 
 func (inst SetBoxFlags) Acronym() string { return "SETBOXF" }
 
+func (inst SetBoxFlags) Execute(ctx vm.ExecutionContext) {
+	ctx.SetBoxFlags(inst.Box.Evaluate(ctx), vm.BoxFlags(inst.Value.Evaluate(ctx)))
+}
+
 type SetBoxScale struct {
 	Box   vm.Param `op:"p8" pos:"1" fmt:"dec"`
 	Value vm.Param `op:"p8" pos:"2" fmt:"dec"`
@@ -20,6 +24,10 @@ type SetBoxScale struct {
 
 func (inst SetBoxScale) Acronym() string { return "SETBOXSCL" }
 
+func (inst SetBoxScale) Execute(ctx vm.ExecutionContext) {
+	ctx.SetBoxScale(inst.Box.Evaluate(ctx), inst.Value.Evaluate(ctx))
+}
+
 type SetBoxScaleInv struct {
 	Box   vm.Param `op:"p8" pos:"1" fmt:"dec"`
 	Value vm.Param `op:"p8" pos:"2" fmt:"dec"`
@@ -27,10 +35,18 @@ type SetBoxScaleInv struct {
 
 func (inst SetBoxScaleInv) Acronym() string { return "SETBOXSCLI" }
 
+func (inst SetBoxScaleInv) Execute(ctx vm.ExecutionContext) {
+	ctx.SetBoxScale(inst.Box.Evaluate(ctx), (inst.Value.Evaluate(ctx)-1)|0x8000)
+}
+
 type CreateBoxMatrix struct{}
 
 func (inst CreateBoxMatrix) Acronym() string { return "INITBOXMTX" }
 
+func (inst CreateBoxMatrix) Execute(ctx vm.ExecutionContext) {
+	ctx.CreateBoxMatrix()
+}
+
 func decodeBoxOp(opcode vm.OpCode, r *vm.BytecodeDecoder) (inst vm.Instruction, err error) {
 	sub := r.DecodeOpCode()
 	switch sub & 0x1F {
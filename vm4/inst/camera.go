@@ -6,4 +6,10 @@ type SetCameraAt struct {
 	X vm.Param `op:"p16" pos:"1" fmt:"dec"`
 }
 
-func (inst SetCameraAt) MNemonic() string { return "SETCAMAT" }
+func (inst SetCameraAt) Acronym() string { return "SETCAMAT" }
+
+func (inst SetCameraAt) Execute(ctx vm.ExecutionContext) {
+	if err := ctx.SetCameraAt(int(int16(inst.X.Evaluate(ctx)))); err != nil {
+		panic(err)
+	}
+}
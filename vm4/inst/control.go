@@ -10,6 +10,10 @@ type StopObjectCode struct{}
 
 func (inst StopObjectCode) Acronym() string { return "SOC" }
 
+func (inst StopObjectCode) Execute(ctx vm.ExecutionContext) {
+	ctx.Stop()
+}
+
 // Jump is a instruction that jumps to the given address. This is also known as JumpRelative in
 // ScummVM.
 type Jump struct {
@@ -18,6 +22,10 @@ type Jump struct {
 
 func (inst Jump) Acronym() string { return "JMP" }
 
+func (inst Jump) Execute(ctx vm.ExecutionContext) {
+	ctx.Jump(uint16(inst.Target.Value))
+}
+
 type UnaryBranch struct {
 	Var    vm.VarRef   `op:"var"`
 	Target vm.Constant `op:"reljmp" fmt:"addr"`
@@ -70,10 +78,22 @@ type StartObject struct {
 
 func (inst StartObject) Acronym() string { return "STOB" }
 
+func (inst StartObject) Execute(ctx vm.ExecutionContext) {
+	obj := vm.ObjectID(inst.Object.Evaluate(ctx))
+	verb := vm.VerbID(inst.Script.Evaluate(ctx))
+	if err := ctx.StartObject(obj, verb, inst.Args.Evaluate(ctx), false); err != nil {
+		panic(err)
+	}
+}
+
 type BreakHere struct{}
 
 func (inst BreakHere) Acronym() string { return "BREAK" }
 
+func (inst BreakHere) Execute(ctx vm.ExecutionContext) {
+	ctx.BreakHere()
+}
+
 // LoadRoom is a instruction that loads a new room.
 type LoadRoom struct {
 	RoomID vm.Param `op:"p8" pos:"1" fmt:"id:room"`
@@ -81,6 +101,12 @@ type LoadRoom struct {
 
 func (inst LoadRoom) Acronym() string { return "LDRO" }
 
+func (inst LoadRoom) Execute(ctx vm.ExecutionContext) {
+	if err := ctx.LoadRoom(vm.RoomID(inst.RoomID.Evaluate(ctx))); err != nil {
+		panic(err)
+	}
+}
+
 type LoadRoomWithEgo struct {
 	Object vm.Param `op:"p16" pos:"1" fmt:"id:object"`
 	Room   vm.Param `op:"p8" pos:"2" fmt:"id:room"`
@@ -90,6 +116,16 @@ type LoadRoomWithEgo struct {
 
 func (inst LoadRoomWithEgo) Acronym() string { return "LDROE" }
 
+func (inst LoadRoomWithEgo) Execute(ctx vm.ExecutionContext) {
+	obj := vm.ObjectID(inst.Object.Evaluate(ctx))
+	room := vm.RoomID(inst.Room.Evaluate(ctx))
+	x := int(int16(inst.XPos.Evaluate(ctx)))
+	y := int(int16(inst.YPos.Evaluate(ctx)))
+	if err := ctx.LoadRoomWithEgo(obj, room, x, y); err != nil {
+		panic(err)
+	}
+}
+
 type BranchUnlessState struct {
 	Object vm.Param    `op:"p16" pos:"1" fmt:"id:object"`
 	State  vm.Param    `op:"p8" pos:"2" fmt:"dec"`
@@ -98,6 +134,13 @@ type BranchUnlessState struct {
 
 func (inst BranchUnlessState) Acronym() string { return "BRST" }
 
+func (inst BranchUnlessState) Execute(ctx vm.ExecutionContext) {
+	obj := ctx.Object(vm.ObjectID(inst.Object.Evaluate(ctx)))
+	if obj.State != vm.ObjectState(inst.State.Evaluate(ctx)) {
+		ctx.Jump(uint16(inst.Target.Value))
+	}
+}
+
 type BranchUnlessNotState struct {
 	Object vm.Param    `op:"p16" pos:"1" fmt:"id:object"`
 	State  vm.Param    `op:"p8" pos:"2" fmt:"dec"`
@@ -106,6 +149,13 @@ type BranchUnlessNotState struct {
 
 func (inst BranchUnlessNotState) Acronym() string { return "BRNST" }
 
+func (inst BranchUnlessNotState) Execute(ctx vm.ExecutionContext) {
+	obj := ctx.Object(vm.ObjectID(inst.Object.Evaluate(ctx)))
+	if obj.State == vm.ObjectState(inst.State.Evaluate(ctx)) {
+		ctx.Jump(uint16(inst.Target.Value))
+	}
+}
+
 type BranchUnlessActorInBox struct {
 	Actor  vm.Param    `op:"p8" pos:"1" fmt:"dec"`
 	Box    vm.Param    `op:"p8" pos:"2" fmt:"dec"`
@@ -114,6 +164,13 @@ type BranchUnlessActorInBox struct {
 
 func (inst BranchUnlessActorInBox) Acronym() string { return "BRAB" }
 
+func (inst BranchUnlessActorInBox) Execute(ctx vm.ExecutionContext) {
+	a := actorParam(ctx, inst.Actor)
+	if !ctx.ActorInBox(a, inst.Box.Evaluate(ctx)) {
+		ctx.Jump(uint16(inst.Target.Value))
+	}
+}
+
 type BranchUnlessClass struct {
 	Object  vm.Param    `op:"p16" pos:"1" fmt:"id:object"`
 	Classes vm.Params   `op:"v16"`
@@ -122,18 +179,36 @@ type BranchUnlessClass struct {
 
 func (inst BranchUnlessClass) Acronym() string { return "BRCL" }
 
+func (inst BranchUnlessClass) Execute(ctx vm.ExecutionContext) {
+	obj := vm.ObjectID(inst.Object.Evaluate(ctx))
+	for _, class := range inst.Classes.Evaluate(ctx) {
+		if ctx.HasClass(obj, vm.ObjectClass(class&0x7F)) != (class&0x80 != 0) {
+			ctx.Jump(uint16(inst.Target.Value))
+			return
+		}
+	}
+}
+
 type Delay struct {
 	Param vm.Param `op:"24" fmt:"dec"`
 }
 
 func (inst Delay) Acronym() string { return "DELAY" }
 
+func (inst Delay) Execute(ctx vm.ExecutionContext) {
+	ctx.Delay(inst.Param.Evaluate(ctx))
+}
+
 type DelayVar struct {
 	Var vm.VarRef `op:"var"`
 }
 
 func (inst DelayVar) Acronym() string { return "DELAYVAR" }
 
+func (inst DelayVar) Execute(ctx vm.ExecutionContext) {
+	ctx.Delay(inst.Var.Read(ctx))
+}
+
 type Debug struct {
 	Param vm.Param `op:"p16" pos:"1" fmt:"dec"`
 }
@@ -93,7 +93,7 @@ type SetCursorImg struct {
 func (inst SetCursorImg) Acronym() string { return "CRIMG" }
 
 func (inst SetCursorImg) Execute(ctx vm.ExecutionContext) {
-	panic("not implemented")
+	panic(vm.ErrNotImplemented)
 }
 
 // SetCursorHotspot is a cursor command that sets the cursor hotspot. This is only used in Loom.
@@ -106,7 +106,7 @@ type SetCursorHotspot struct {
 func (inst SetCursorHotspot) Acronym() string { return "CRHOT" }
 
 func (inst SetCursorHotspot) Execute(ctx vm.ExecutionContext) {
-	panic("not implemented")
+	panic(vm.ErrNotImplemented)
 }
 
 // CursorSelect is a cursor command to select the current cursor.
@@ -127,6 +127,12 @@ type CharsetSelect struct {
 
 func (inst CharsetSelect) Acronym() string { return "CHSEL" }
 
+func (inst CharsetSelect) Execute(ctx vm.ExecutionContext) {
+	if err := ctx.InitCharset(vm.CharsetID(inst.Charset.Evaluate(ctx))); err != nil {
+		panic(err)
+	}
+}
+
 func decodeCursorCommand(opcode vm.OpCode, r *vm.BytecodeDecoder) (inst vm.Instruction, err error) {
 	sub := r.DecodeOpCode()
 
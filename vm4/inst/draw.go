@@ -6,11 +6,19 @@ import (
 
 type DrawObject struct {
 	Object vm.Param `op:"p16" pos:"1" fmt:"id:object"`
-	XPos   vm.Param `op:"p16" pos:"1" fmt:"dec"` // pos respect the sub-opcode
-	YPos   vm.Param `op:"p16" pos:"2" fmt:"dec"` // pos respect the sub-opcode
+	XPos   vm.Param `op:"p16" pos:"2" fmt:"dec"`
+	YPos   vm.Param `op:"p16" pos:"3" fmt:"dec"`
 }
 
-func (inst DrawObject) MNemonic() string { return "DRAWOBJ" }
+func (inst DrawObject) Acronym() string { return "DRAWOBJ" }
+
+func (inst DrawObject) Execute(ctx vm.ExecutionContext) {
+	ctx.DrawObject(
+		vm.ObjectID(inst.Object.Evaluate(ctx)),
+		inst.XPos.Evaluate(ctx),
+		inst.YPos.Evaluate(ctx),
+	)
+}
 
 type DrawBox struct {
 	Left vm.Param `op:"p16" pos:"1" fmt:"dec"`
@@ -21,7 +29,7 @@ type DrawBox struct {
 	Color  vm.Param `op:"p8" pos:"3" fmt:"dec"`
 }
 
-func (inst DrawBox) MNemonic() string { return "DRAWBOX" }
+func (inst DrawBox) Acronym() string { return "DRAWBOX" }
 
 func (inst *DrawBox) DecodeOperands(opcode vm.OpCode, r *vm.BytecodeDecoder) error {
 	inst.Left = r.DecodeWordParam(opcode, vm.ParamPos1, vm.NumberFormatDecimal)
@@ -0,0 +1,104 @@
+package inst_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/apoloval/scumm-go/vm/vmtest"
+	"github.com/apoloval/scumm-go/vm4/inst"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteInst(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		bytecode *vmtest.Bytecode
+		setup    func(ctx *vmtest.Context)
+		addr     uint16
+		err      error
+		accesses []vmtest.Access
+		check    func(t *testing.T, ctx *vmtest.Context)
+	}{
+		{
+			name:     "MoveWord",
+			bytecode: vmtest.NewBytecode().Op(0x1A).Var(100).Word(42),
+			addr:     0x0005,
+			accesses: []vmtest.Access{vmtest.Write("VAR_100", 42)},
+			check: func(t *testing.T, ctx *vmtest.Context) {
+				vmtest.AssertWords(t, ctx, map[uint16]int{100: 42})
+			},
+		},
+		{
+			name:     "MoveLocalToWord",
+			bytecode: vmtest.NewBytecode().Op(0x9A).Var(100).Local(2),
+			setup:    func(ctx *vmtest.Context) { ctx.Locals[2] = 7 },
+			addr:     0x0005,
+			accesses: []vmtest.Access{vmtest.Read("LOCAL_2", 7), vmtest.Write("VAR_100", 7)},
+		},
+		{
+			name:     "MoveBit",
+			bytecode: vmtest.NewBytecode().Op(0x1A).Bit(5).Word(1),
+			addr:     0x0005,
+			accesses: []vmtest.Access{vmtest.Write("BIT_5", 1)},
+			check: func(t *testing.T, ctx *vmtest.Context) {
+				vmtest.AssertBits(t, ctx, map[uint16]bool{5: true, 6: false})
+			},
+		},
+		{
+			name: "Jump",
+			bytecode: vmtest.NewBytecode().
+				Op(0x18).JumpTo(0x0008).
+				Op(0x1A).Var(1).Word(1).
+				Op(0x1A).Var(2).Word(2),
+			addr:     0x000D,
+			accesses: []vmtest.Access{vmtest.Write("VAR_2", 2)},
+		},
+		{
+			name: "BreakHere",
+			bytecode: vmtest.NewBytecode().
+				Op(0x80).
+				Op(0x1A).Var(1).Word(1),
+			addr: 0x0001,
+			check: func(t *testing.T, ctx *vmtest.Context) {
+				assert.True(t, ctx.Yielded)
+				assert.False(t, ctx.Stopped)
+			},
+		},
+		{
+			name: "StartScriptAndStop",
+			bytecode: vmtest.NewBytecode().
+				Op(0x0A, 0x05).Byte(0x01).Word(7).Byte(0xFF).
+				Op(0xA0).
+				Op(0x1A).Var(1).Word(1),
+			addr: 0x0007,
+			check: func(t *testing.T, ctx *vmtest.Context) {
+				assert.Equal(t, []vmtest.StartedScript{{ID: 5, Args: []int{7}}}, ctx.Started)
+				assert.True(t, ctx.Stopped)
+			},
+		},
+		{
+			name:     "NotImplemented",
+			bytecode: vmtest.NewBytecode().Op(0x6B).Word(7),
+			addr:     0x0000,
+			err:      vm.ErrNotImplemented,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := vmtest.NewContext()
+			if testCase.setup != nil {
+				testCase.setup(ctx)
+			}
+			addr, err := ctx.Run(inst.Decode, testCase.bytecode.Bytes())
+			if testCase.err != nil {
+				assert.ErrorIs(t, err, testCase.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.addr, addr)
+			vmtest.AssertAccesses(t, ctx, testCase.accesses...)
+			if testCase.check != nil {
+				testCase.check(t, ctx)
+			}
+		})
+	}
+}
@@ -9,6 +9,10 @@ type GetInventoryCount struct {
 
 func (inst GetInventoryCount) Acronym() string { return "INVCNT" }
 
+func (inst GetInventoryCount) Execute(ctx vm.ExecutionContext) {
+	inst.Result.Write(ctx, ctx.InventoryCount(vm.ObjectOwner(inst.Actor.Evaluate(ctx))))
+}
+
 type FindInventory struct {
 	Result vm.VarRef `op:"result"`
 	Owner  vm.Param  `op:"p8" pos:"1" fmt:"id:actor"`
@@ -16,3 +20,8 @@ type FindInventory struct {
 }
 
 func (inst FindInventory) Acronym() string { return "FINDINV" }
+
+func (inst FindInventory) Execute(ctx vm.ExecutionContext) {
+	owner := vm.ObjectOwner(inst.Owner.Evaluate(ctx))
+	inst.Result.Write(ctx, int(ctx.FindInventory(owner, inst.Index.Evaluate(ctx))))
+}
@@ -9,6 +9,12 @@ type PickUpObject struct {
 
 func (inst PickUpObject) Acronym() string { return "PICK" }
 
+func (inst PickUpObject) Execute(ctx vm.ExecutionContext) {
+	if err := ctx.PickUpObject(vm.ObjectID(inst.Object.Evaluate(ctx))); err != nil {
+		panic(err)
+	}
+}
+
 type FindObject struct {
 	Result vm.VarRef `op:"result"`
 	X      vm.Param  `op:"p8" pos:"1" fmt:"dec"`
@@ -17,6 +23,11 @@ type FindObject struct {
 
 func (inst FindObject) Acronym() string { return "FINDOBJ" }
 
+func (inst FindObject) Execute(ctx vm.ExecutionContext) {
+	obj := ctx.FindObject(inst.X.Evaluate(ctx), inst.Y.Evaluate(ctx))
+	inst.Result.Write(ctx, int(obj))
+}
+
 type SetClass struct {
 	Object  vm.Param  `op:"p16" pos:"1" fmt:"id:object"`
 	Classes vm.Params `op:"v16"`
@@ -24,6 +35,13 @@ type SetClass struct {
 
 func (inst SetClass) Acronym() string { return "SOCL" }
 
+func (inst SetClass) Execute(ctx vm.ExecutionContext) {
+	obj := vm.ObjectID(inst.Object.Evaluate(ctx))
+	for _, class := range inst.Classes.Evaluate(ctx) {
+		ctx.SetClass(obj, vm.ObjectClass(class&0x7F), class&0x80 != 0)
+	}
+}
+
 type SetObjectName struct {
 	Object vm.Param `op:"p16" pos:"1" fmt:"id:object"`
 	Name   string   `op:"string"`
@@ -31,6 +49,10 @@ type SetObjectName struct {
 
 func (inst SetObjectName) Acronym() string { return "SONM" }
 
+func (inst SetObjectName) Execute(ctx vm.ExecutionContext) {
+	ctx.SetObjectName(vm.ObjectID(inst.Object.Evaluate(ctx)), inst.Name)
+}
+
 type GetObjectOwner struct {
 	Result vm.VarRef `op:"result"`
 	Object vm.Param  `op:"p16" pos:"1" fmt:"id:object"`
@@ -38,6 +60,11 @@ type GetObjectOwner struct {
 
 func (inst GetObjectOwner) Acronym() string { return "GOOW" }
 
+func (inst GetObjectOwner) Execute(ctx vm.ExecutionContext) {
+	obj := ctx.Object(vm.ObjectID(inst.Object.Evaluate(ctx)))
+	inst.Result.Write(ctx, int(obj.Owner))
+}
+
 type SetObjectOwner struct {
 	Object vm.Param `op:"p16" pos:"1" fmt:"id:object"`
 	Owner  vm.Param `op:"p8" pos:"2" fmt:"id:actor"`
@@ -45,6 +72,13 @@ type SetObjectOwner struct {
 
 func (inst SetObjectOwner) Acronym() string { return "SOOW" }
 
+func (inst SetObjectOwner) Execute(ctx vm.ExecutionContext) {
+	obj := vm.ObjectID(inst.Object.Evaluate(ctx))
+	if err := ctx.SetObjectOwner(obj, vm.ObjectOwner(inst.Owner.Evaluate(ctx))); err != nil {
+		panic(err)
+	}
+}
+
 type SetObjectState struct {
 	Object vm.Param `op:"p16" pos:"1" fmt:"id:object"`
 	State  vm.Param `op:"p8" pos:"2" fmt:"hex"`
@@ -52,6 +86,11 @@ type SetObjectState struct {
 
 func (inst SetObjectState) Acronym() string { return "SOST" }
 
+func (inst SetObjectState) Execute(ctx vm.ExecutionContext) {
+	obj := vm.ObjectID(inst.Object.Evaluate(ctx))
+	ctx.SetObjectState(obj, vm.ObjectState(inst.State.Evaluate(ctx)))
+}
+
 type GetDistance struct {
 	Result vm.VarRef `op:"result"`
 	Obj1   vm.Param  `op:"p16" pos:"1" fmt:"id:object"`
@@ -59,3 +98,7 @@ type GetDistance struct {
 }
 
 func (inst GetDistance) Acronym() string { return "DIST" }
+
+func (inst GetDistance) Execute(ctx vm.ExecutionContext) {
+	inst.Result.Write(ctx, ctx.Distance(inst.Obj1.Evaluate(ctx), inst.Obj2.Evaluate(ctx)))
+}
@@ -6,6 +6,12 @@ import (
 	"github.com/apoloval/scumm-go/vm"
 )
 
+// PrintOp is a sub-operation of a print instruction, applied to the text style in script order.
+type PrintOp interface {
+	Display(st *vm.SymbolTable) string
+	apply(ctx vm.ExecutionContext, style *vm.TextStyle)
+}
+
 type PrintPos struct {
 	XPos vm.Param
 	YPos vm.Param
@@ -15,6 +21,12 @@ func (inst PrintPos) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("POS=[%s,%s]", inst.XPos.Display(st), inst.YPos.Display(st))
 }
 
+func (inst PrintPos) apply(ctx vm.ExecutionContext, style *vm.TextStyle) {
+	style.X = int(int16(inst.XPos.Evaluate(ctx)))
+	style.Y = int(int16(inst.YPos.Evaluate(ctx)))
+	style.Overhead = false
+}
+
 type PrintColor struct {
 	Color vm.Param
 }
@@ -23,6 +35,10 @@ func (inst PrintColor) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("COL=%s", inst.Color.Display(st))
 }
 
+func (inst PrintColor) apply(ctx vm.ExecutionContext, style *vm.TextStyle) {
+	style.Color = inst.Color.Evaluate(ctx)
+}
+
 type PrintClipped struct {
 	Right vm.Param
 }
@@ -31,6 +47,10 @@ func (inst PrintClipped) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("CLI=%s", inst.Right.Display(st))
 }
 
+func (inst PrintClipped) apply(ctx vm.ExecutionContext, style *vm.TextStyle) {
+	style.Right = int(int16(inst.Right.Evaluate(ctx)))
+}
+
 type PrintErase struct {
 	Width  vm.Param
 	Height vm.Param
@@ -40,18 +60,37 @@ func (inst PrintErase) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("ERA=[%s,%s]", inst.Width.Display(st), inst.Height.Display(st))
 }
 
+func (inst PrintErase) apply(ctx vm.ExecutionContext, style *vm.TextStyle) {
+	// The original interpreters do not implement the erase option either.
+	inst.Width.Evaluate(ctx)
+	inst.Height.Evaluate(ctx)
+}
+
 type PrintCenter struct{}
 
 func (inst PrintCenter) Display(st *vm.SymbolTable) string { return "CENT" }
 
+func (inst PrintCenter) apply(ctx vm.ExecutionContext, style *vm.TextStyle) {
+	style.Center = true
+	style.Overhead = false
+}
+
 type PrintLeft struct{}
 
 func (inst PrintLeft) Display(st *vm.SymbolTable) string { return "LEFT" }
 
+func (inst PrintLeft) apply(ctx vm.ExecutionContext, style *vm.TextStyle) {
+	style.Center = false
+}
+
 type PrintOverhead struct{}
 
 func (inst PrintOverhead) Display(st *vm.SymbolTable) string { return "OVER" }
 
+func (inst PrintOverhead) apply(ctx vm.ExecutionContext, style *vm.TextStyle) {
+	style.Overhead = true
+}
+
 type PrintText struct {
 	Text string
 }
@@ -61,169 +100,130 @@ func (inst PrintText) Display(st *vm.SymbolTable) string {
 }
 
 type Print struct {
-	Actor    vm.Param
-	Pos      *PrintPos
-	Color    *PrintColor
-	Clipped  *PrintClipped
-	Erase    *PrintErase
-	Center   *PrintCenter
-	Left     *PrintLeft
-	Overhead *PrintOverhead
-	Text     *PrintText
+	Actor vm.Param
+
+	// Ops are the sub-operations in the order they appear in the script.
+	Ops []PrintOp
+
+	// Text is the text to print, or nil if the options only change the defaults of the text slot.
+	Text *PrintText
 }
 
 func (inst Print) Acronym() string { return "PRINT" }
 
-func (inst Print) DisplayOperands(st *vm.SymbolTable) []string {
-	var props []string
-	if inst.Pos != nil {
-		props = append(props, inst.Pos.Display(st))
-	}
-	if inst.Color != nil {
-		props = append(props, inst.Color.Display(st))
-	}
-	if inst.Clipped != nil {
-		props = append(props, inst.Clipped.Display(st))
-	}
-	if inst.Erase != nil {
-		props = append(props, inst.Erase.Display(st))
+func (inst Print) Execute(ctx vm.ExecutionContext) {
+	actor := inst.Actor.Evaluate(ctx)
+	slot := vm.TextSlotFor(actor)
+	style := ctx.LoadTextStyle(slot)
+	for _, op := range inst.Ops {
+		op.apply(ctx, style)
 	}
-	if inst.Center != nil {
-		props = append(props, inst.Center.Display(st))
+	if inst.Text == nil {
+		// Without text, the options become the defaults of the text slot.
+		ctx.SaveTextStyle(slot)
+		return
 	}
-	if inst.Left != nil {
-		props = append(props, inst.Left.Display(st))
-	}
-	if inst.Overhead != nil {
-		props = append(props, inst.Overhead.Display(st))
-	}
-	if inst.Text != nil {
-		props = append(props, inst.Text.Display(st))
+	if err := ctx.Print(actor, inst.Text.Text); err != nil {
+		panic(err)
 	}
+}
 
-	return append([]string{inst.Actor.Display(st)}, props...)
+func (inst Print) DisplayOperands(st *vm.SymbolTable) []string {
+	return append([]string{inst.Actor.Display(st)}, displayPrintOps(st, inst.Ops, inst.Text)...)
 }
 
 func (inst *Print) DecodeOperands(opcode vm.OpCode, r *vm.BytecodeDecoder) error {
 	inst.Actor = r.DecodeByteParam(opcode, vm.ParamPos1, vm.NumberFormatActorID)
-	for {
-		sub := r.DecodeOpCode()
-		if sub == 0xFF {
-			return nil
-		}
-		switch sub & 0x0F {
-		case 0x00:
-			inst.Pos = &PrintPos{
-				XPos: r.DecodeWordParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
-				YPos: r.DecodeWordParam(sub, vm.ParamPos2, vm.NumberFormatDecimal),
-			}
-		case 0x01:
-			inst.Color = &PrintColor{
-				Color: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
-			}
-		case 0x02:
-			inst.Clipped = &PrintClipped{
-				Right: r.DecodeWordParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
-			}
-		case 0x03:
-			inst.Erase = &PrintErase{
-				Width:  r.DecodeWordParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
-				Height: r.DecodeWordParam(sub, vm.ParamPos2, vm.NumberFormatDecimal),
-			}
-		case 0x04:
-			inst.Center = &PrintCenter{}
-		case 0x06:
-			inst.Left = &PrintLeft{}
-		case 0x07:
-			inst.Overhead = &PrintOverhead{}
-		case 0x0F:
-			inst.Text = &PrintText{Text: r.DecodeString()}
-			return nil // If given, this is always the last operation of a print instruction.
-		default:
-			return fmt.Errorf("unknown sub-opcode %02X for print op operation", sub)
-		}
+	ops, text, err := decodePrintOps(r)
+	if err != nil {
+		return fmt.Errorf("%w for print op operation", err)
 	}
+	inst.Ops, inst.Text = ops, text
+	return nil
 }
 
 type PrintEgo struct {
-	Pos      *PrintPos
-	Color    *PrintColor
-	Clipped  *PrintClipped
-	Erase    *PrintErase
-	Center   *PrintCenter
-	Left     *PrintLeft
-	Overhead *PrintOverhead
-	Text     *PrintText
+	// Ops are the sub-operations in the order they appear in the script.
+	Ops []PrintOp
+
+	// Text is the text to print, or nil if the options only change the defaults of the text slot.
+	Text *PrintText
 }
 
 func (inst PrintEgo) Acronym() string { return "PRTEGO" }
 
+func (inst PrintEgo) Execute(ctx vm.ExecutionContext) {
+	Print{
+		Actor: vm.Constant{Value: ctx.ReadWord(vm.VarEgo)},
+		Ops:   inst.Ops,
+		Text:  inst.Text,
+	}.Execute(ctx)
+}
+
 func (inst PrintEgo) DisplayOperands(st *vm.SymbolTable) []string {
-	var props []string
-	if inst.Pos != nil {
-		props = append(props, inst.Pos.Display(st))
-	}
-	if inst.Color != nil {
-		props = append(props, inst.Color.Display(st))
-	}
-	if inst.Clipped != nil {
-		props = append(props, inst.Clipped.Display(st))
-	}
-	if inst.Erase != nil {
-		props = append(props, inst.Erase.Display(st))
-	}
-	if inst.Center != nil {
-		props = append(props, inst.Center.Display(st))
-	}
-	if inst.Left != nil {
-		props = append(props, inst.Left.Display(st))
+	return displayPrintOps(st, inst.Ops, inst.Text)
+}
+
+func (inst *PrintEgo) DecodeOperands(opcode vm.OpCode, r *vm.BytecodeDecoder) error {
+	ops, text, err := decodePrintOps(r)
+	if err != nil {
+		return fmt.Errorf("%w for print ego op operation", err)
 	}
-	if inst.Overhead != nil {
-		props = append(props, inst.Overhead.Display(st))
+	inst.Ops, inst.Text = ops, text
+	return nil
+}
+
+func displayPrintOps(st *vm.SymbolTable, ops []PrintOp, text *PrintText) []string {
+	var props []string
+	for _, op := range ops {
+		props = append(props, op.Display(st))
 	}
-	if inst.Text != nil {
-		props = append(props, inst.Text.Display(st))
+	if text != nil {
+		props = append(props, text.Display(st))
 	}
-
 	return props
 }
 
-func (inst *PrintEgo) DecodeOperands(opcode vm.OpCode, r *vm.BytecodeDecoder) error {
+// decodePrintOps decodes the sub-operations of a print instruction up to its end or its text.
+func decodePrintOps(r *vm.BytecodeDecoder) ([]PrintOp, *PrintText, error) {
+	var ops []PrintOp
 	for {
 		sub := r.DecodeOpCode()
 		if sub == 0xFF {
-			return nil
+			return ops, nil, nil
 		}
+		var op PrintOp
 		switch sub & 0x0F {
 		case 0x00:
-			inst.Pos = &PrintPos{
+			op = PrintPos{
 				XPos: r.DecodeWordParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 				YPos: r.DecodeWordParam(sub, vm.ParamPos2, vm.NumberFormatDecimal),
 			}
 		case 0x01:
-			inst.Color = &PrintColor{
+			op = PrintColor{
 				Color: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 			}
 		case 0x02:
-			inst.Clipped = &PrintClipped{
+			op = PrintClipped{
 				Right: r.DecodeWordParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 			}
 		case 0x03:
-			inst.Erase = &PrintErase{
+			op = PrintErase{
 				Width:  r.DecodeWordParam(sub, vm.ParamPos1, vm.NumberFormatDecimal),
 				Height: r.DecodeWordParam(sub, vm.ParamPos2, vm.NumberFormatDecimal),
 			}
 		case 0x04:
-			inst.Center = &PrintCenter{}
+			op = PrintCenter{}
 		case 0x06:
-			inst.Left = &PrintLeft{}
+			op = PrintLeft{}
 		case 0x07:
-			inst.Overhead = &PrintOverhead{}
+			op = PrintOverhead{}
 		case 0x0F:
-			inst.Text = &PrintText{Text: r.DecodeString()}
-			return nil // If given, this is always the last operation of a print instruction.
+			// If given, this is always the last operation of a print instruction.
+			return ops, &PrintText{Text: r.DecodeString()}, nil
 		default:
-			return fmt.Errorf("unknown sub-opcode %02X for print ego op operation", sub)
+			return nil, nil, fmt.Errorf("unknown sub-opcode %02X", sub)
 		}
+		ops = append(ops, op)
 	}
 }
@@ -27,6 +27,13 @@ type PseudoRoom struct {
 
 func (inst PseudoRoom) Acronym() string { return "PSRO" }
 
+func (inst PseudoRoom) Execute(ctx vm.ExecutionContext) {
+	room := vm.RoomID(inst.Value.Evaluate(ctx))
+	for _, id := range inst.ResourceIDs {
+		ctx.MapPseudoRoom(vm.RoomID(id.Evaluate(ctx)), room)
+	}
+}
+
 func (inst PseudoRoom) DisplayOperands(st *vm.SymbolTable) (ops []string) {
 	ops = []string{inst.Value.Display(st)}
 	for _, id := range inst.ResourceIDs {
@@ -52,6 +59,10 @@ type RoomSetScrollLimits struct {
 
 func (inst RoomSetScrollLimits) Acronym() string { return "ROSL" }
 
+func (inst RoomSetScrollLimits) Execute(ctx vm.ExecutionContext) {
+	ctx.SetScrollLimits(inst.MinX.Evaluate(ctx), inst.MaxX.Evaluate(ctx))
+}
+
 type RoomColor struct {
 	Color vm.Param `op:"p16" pos:"1" fmt:"hex"`
 	Index vm.Param `op:"p16" pos:"2" fmt:"dec"`
@@ -66,6 +77,10 @@ type RoomInitScreen struct {
 
 func (inst RoomInitScreen) Acronym() string { return "ROIS" }
 
+func (inst RoomInitScreen) Execute(ctx vm.ExecutionContext) {
+	ctx.InitScreen(inst.B.Evaluate(ctx), inst.H.Evaluate(ctx))
+}
+
 type RoomShadowColor struct {
 	Color vm.Param `op:"p16" pos:"1" fmt:"hex"`
 	Index vm.Param `op:"p16" pos:"2" fmt:"dec"`
@@ -106,6 +121,10 @@ type RoomSaveGame struct {
 
 func (inst RoomSaveGame) Acronym() string { return "ROSAVE" }
 
+func (inst RoomSaveGame) Execute(ctx vm.ExecutionContext) {
+	ctx.SaveLoadGame(inst.LoadFlag.Evaluate(ctx), inst.LoadSlot.Evaluate(ctx))
+}
+
 type RoomIntensityRGB struct {
 	RedScale   vm.Param `op:"p16" pos:"1" fmt:"dec"`
 	GreenScale vm.Param `op:"p16" pos:"2" fmt:"dec"`
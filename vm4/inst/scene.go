@@ -12,10 +12,18 @@ type BeginOverride struct {
 
 func (inst BeginOverride) Acronym() string { return "BEGOVER" }
 
+func (inst BeginOverride) Execute(ctx vm.ExecutionContext) {
+	ctx.BeginOverride(uint16(inst.Target.Value))
+}
+
 type EndOverride struct{}
 
 func (inst EndOverride) Acronym() string { return "ENDOVER" }
 
+func (inst EndOverride) Execute(ctx vm.ExecutionContext) {
+	ctx.EndOverride()
+}
+
 func decodeOverrideOp(opcode vm.OpCode, r *vm.BytecodeDecoder) (inst vm.Instruction, err error) {
 	sub := r.DecodeOpCode()
 	switch sub & 0x1F {
@@ -27,6 +27,13 @@ func (inst *StartScript) DecodeOperands(opcode vm.OpCode, r *vm.BytecodeDecoder)
 
 func (inst StartScript) Acronym() string { return "STRSC" }
 
+func (inst StartScript) Execute(ctx vm.ExecutionContext) {
+	id := vm.ScriptID(inst.ScriptID.Evaluate(ctx))
+	if err := ctx.StartScript(id, inst.Args.Evaluate(ctx), inst.Recursive); err != nil {
+		panic(err)
+	}
+}
+
 func (inst StartScript) DisplayOperands(st *vm.SymbolTable) (ops []string) {
 	var flags string
 	if inst.Recursive {
@@ -57,6 +64,10 @@ type StopObjectScript struct {
 
 func (inst StopObjectScript) Acronym() string { return "STOPOBJSCR" }
 
+func (inst StopObjectScript) Execute(ctx vm.ExecutionContext) {
+	ctx.StopObjectScript(vm.ObjectID(inst.Script.Evaluate(ctx)))
+}
+
 type ChainStript struct {
 	Script vm.Param  `op:"p8" pos:"1" fmt:"id:script"`
 	Args   vm.Params `op:"v16"`
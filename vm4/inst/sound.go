@@ -8,23 +8,51 @@ type StartMusic struct {
 
 func (inst StartMusic) Acronym() string { return "STARTMUS" }
 
+func (inst StartMusic) Execute(ctx vm.ExecutionContext) {
+	if err := ctx.StartMusic(vm.SoundID(inst.Music.Evaluate(ctx))); err != nil {
+		panic(err)
+	}
+}
+
 type StopMusic struct{}
 
 func (inst StopMusic) Acronym() string { return "STOPMUS" }
 
+func (inst StopMusic) Execute(ctx vm.ExecutionContext) {
+	ctx.StopMusic()
+}
+
 type StartSound struct {
 	Sound vm.Param `op:"p8" pos:"1" fmt:"id:sound"`
 }
 
 func (inst StartSound) Acronym() string { return "STARTSND" }
 
+func (inst StartSound) Execute(ctx vm.ExecutionContext) {
+	if err := ctx.StartSound(vm.SoundID(inst.Sound.Evaluate(ctx))); err != nil {
+		panic(err)
+	}
+}
+
 type StopSound struct {
 	Sound vm.Param `op:"p8" pos:"1" fmt:"id:sound"`
 }
 
+func (inst StopSound) Execute(ctx vm.ExecutionContext) {
+	ctx.StopSound(vm.SoundID(inst.Sound.Evaluate(ctx)))
+}
+
 type IsSoundRunning struct {
 	Result vm.VarRef `op:"result"`
 	Sound  vm.Param  `op:"p8" pos:"1" fmt:"id:sound"`
 }
 
 func (inst IsSoundRunning) Acronym() string { return "SNDRUN" }
+
+func (inst IsSoundRunning) Execute(ctx vm.ExecutionContext) {
+	running := 0
+	if ctx.IsSoundRunning(vm.SoundID(inst.Sound.Evaluate(ctx))) {
+		running = 1
+	}
+	inst.Result.Write(ctx, running)
+}
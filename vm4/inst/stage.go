@@ -12,6 +12,10 @@ type PanCameraTo struct {
 
 func (inst PanCameraTo) Acronym() string { return "PANC" }
 
+func (inst PanCameraTo) Execute(ctx vm.ExecutionContext) {
+	ctx.PanCameraTo(int(int16(inst.X.Evaluate(ctx))))
+}
+
 type DoSentence struct {
 	Verb vm.Param `op:"p8" pos:"1" fmt:"id:verb"`
 	Obj1 vm.Param `op:"p16" pos:"2" fmt:"id:object"`
@@ -31,24 +35,61 @@ func (inst *DoSentence) DecodeOperands(opcode vm.OpCode, r *vm.BytecodeDecoder)
 	return nil
 }
 
+func (inst DoSentence) Execute(ctx vm.ExecutionContext) {
+	verb := inst.Verb.Evaluate(ctx)
+	if verb == 0xFE {
+		ctx.StopSentence()
+		return
+	}
+	objA := vm.ObjectID(inst.Obj1.Evaluate(ctx))
+	objB := vm.ObjectID(inst.Obj2.Evaluate(ctx))
+	if err := ctx.DoSentence(vm.VerbID(verb), objA, objB); err != nil {
+		panic(err)
+	}
+}
+
 type WaitForActor struct {
 	Actor vm.Param `op:"p8" pos:"1" fmt:"id:actor"`
 }
 
 func (inst WaitForActor) Acronym() string { return "WAITA" }
 
+func (inst WaitForActor) Execute(ctx vm.ExecutionContext) {
+	if a := actorParam(ctx, inst.Actor); a.Moving != 0 {
+		ctx.Retry()
+	}
+}
+
 type WaitForMessage struct{}
 
 func (inst WaitForMessage) Acronym() string { return "WAITM" }
 
+func (inst WaitForMessage) Execute(ctx vm.ExecutionContext) {
+	if ctx.ReadWord(vm.VarHaveMsg) != 0 {
+		ctx.Retry()
+	}
+}
+
 type WaitForCamera struct{}
 
 func (inst WaitForCamera) Acronym() string { return "WAITC" }
 
+func (inst WaitForCamera) Execute(ctx vm.ExecutionContext) {
+	if ctx.IsCameraMoving() {
+		ctx.Retry()
+	}
+}
+
 type WaitForSentence struct{}
 
 func (inst WaitForSentence) Acronym() string { return "WAITS" }
 
+func (inst WaitForSentence) Execute(ctx vm.ExecutionContext) {
+	if ctx.IsSentenceRunning() {
+		ctx.Retry()
+	}
+}
+
 func decodeWaitOp(opcode vm.OpCode, r *vm.BytecodeDecoder) (inst vm.Instruction, err error) {
 	sub := r.DecodeOpCode()
 	switch sub & 0x1F {
@@ -72,10 +113,22 @@ type CutScene struct {
 
 func (inst CutScene) Acronym() string { return "CUTSCE" }
 
+func (inst CutScene) Execute(ctx vm.ExecutionContext) {
+	if err := ctx.BeginCutscene(inst.Args.Evaluate(ctx)); err != nil {
+		panic(err)
+	}
+}
+
 type EndCutScene struct{}
 
 func (inst EndCutScene) Acronym() string { return "ENDCUT" }
 
+func (inst EndCutScene) Execute(ctx vm.ExecutionContext) {
+	if err := ctx.EndCutscene(); err != nil {
+		panic(err)
+	}
+}
+
 type Lights struct {
 	Arg1 vm.Param    `op:"p8" pos:"1" fmt:"dec"`
 	Arg2 vm.Constant `op:"8" fmt:"dec"`
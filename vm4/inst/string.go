@@ -14,6 +14,12 @@ type LoadString struct {
 
 func (inst LoadString) Acronym() string { return "LDSTR" }
 
+func (inst LoadString) Execute(ctx vm.ExecutionContext) {
+	if err := ctx.LoadString(vm.StringID(inst.StrID.Evaluate(ctx)), inst.Val); err != nil {
+		panic(err)
+	}
+}
+
 type CopyString struct {
 	Dest vm.Param `op:"p8" pos:"1" fmt:"id:string"`
 	Src  vm.Param `op:"p8" pos:"2" fmt:"id:string"`
@@ -21,6 +27,14 @@ type CopyString struct {
 
 func (inst CopyString) Acronym() string { return "CPSTR" }
 
+func (inst CopyString) Execute(ctx vm.ExecutionContext) {
+	dst := vm.StringID(inst.Dest.Evaluate(ctx))
+	src := vm.StringID(inst.Src.Evaluate(ctx))
+	if err := ctx.CopyString(dst, src); err != nil {
+		panic(err)
+	}
+}
+
 // WriteChar is an instruction that writes a character into a string resource.
 type WriteChar struct {
 	String vm.Param    `op:"p8" pos:"1" fmt:"id:string"`
@@ -30,6 +44,13 @@ type WriteChar struct {
 
 func (inst WriteChar) Acronym() string { return "WSTR" }
 
+func (inst WriteChar) Execute(ctx vm.ExecutionContext) {
+	id := vm.StringID(inst.String.Evaluate(ctx))
+	if err := ctx.WriteChar(id, inst.Index.Evaluate(ctx), byte(inst.Val.Value)); err != nil {
+		panic(err)
+	}
+}
+
 type ReadChar struct {
 	Result vm.VarRef `op:"result"`
 	String vm.Param  `op:"p8" pos:"1" fmt:"id:string"`
@@ -38,6 +59,14 @@ type ReadChar struct {
 
 func (inst ReadChar) Acronym() string { return "RCHAR" }
 
+func (inst ReadChar) Execute(ctx vm.ExecutionContext) {
+	ch, err := ctx.ReadChar(vm.StringID(inst.String.Evaluate(ctx)), inst.Index.Evaluate(ctx))
+	if err != nil {
+		panic(err)
+	}
+	inst.Result.Write(ctx, int(ch))
+}
+
 // NewString is an instruction that allocates a new string resource.
 type NewString struct {
 	String vm.Param `op:"p8" pos:"1" fmt:"id:string"`
@@ -46,6 +75,13 @@ type NewString struct {
 
 func (inst NewString) Acronym() string { return "NEWSTR" }
 
+func (inst NewString) Execute(ctx vm.ExecutionContext) {
+	id := vm.StringID(inst.String.Evaluate(ctx))
+	if err := ctx.NewString(id, inst.Size.Evaluate(ctx)); err != nil {
+		panic(err)
+	}
+}
+
 func decodeStringOp(opcode vm.OpCode, r *vm.BytecodeDecoder) (inst vm.Instruction, err error) {
 	sub := r.DecodeOpCode()
 	switch sub & 0x1F {
@@ -72,3 +108,8 @@ type StringWidth struct {
 }
 
 func (inst StringWidth) Acronym() string { return "STRW" }
+
+func (inst StringWidth) Execute(ctx vm.ExecutionContext) {
+	text := ctx.ReadString(vm.StringID(inst.String.Evaluate(ctx)))
+	inst.Result.Write(ctx, ctx.StringWidth(text))
+}
@@ -50,6 +50,12 @@ func (inst *SetVarRange) DecodeOperands(opcode vm.OpCode, r *vm.BytecodeDecoder)
 	return nil
 }
 
+// SaveVarsOp is a sub-operation of a save vars instruction, executed in script order.
+type SaveVarsOp interface {
+	Display(st *vm.SymbolTable) string
+	apply(ctx vm.ExecutionContext)
+}
+
 type SaveVarsWriteVars struct {
 	ResultA vm.VarRef `op:"result"`
 	ResultB vm.VarRef `op:"result"`
@@ -59,6 +65,16 @@ func (inst SaveVarsWriteVars) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("VARS=[%s, %s]", inst.ResultA.Display(st), inst.ResultB.Display(st))
 }
 
+func (inst SaveVarsWriteVars) apply(ctx vm.ExecutionContext) {
+	var values []int
+	for id := int(inst.ResultA.VarID); id <= int(inst.ResultB.VarID); id++ {
+		values = append(values, vm.VarRef{VarID: uint16(id)}.Read(ctx))
+	}
+	if err := ctx.WriteVarsFile(values); err != nil {
+		panic(err)
+	}
+}
+
 type SaveVarsWriteStrings struct {
 	Arg1 vm.Param `op:"p8" pos:"1" fmt:"id:string"`
 	Arg2 vm.Param `op:"p8" pos:"2" fmt:"id:string"`
@@ -68,6 +84,13 @@ func (inst SaveVarsWriteStrings) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("STRINGS=[%s, %s]", inst.Arg1.Display(st), inst.Arg2.Display(st))
 }
 
+func (inst SaveVarsWriteStrings) apply(ctx vm.ExecutionContext) {
+	first, last := vm.StringID(inst.Arg1.Evaluate(ctx)), vm.StringID(inst.Arg2.Evaluate(ctx))
+	if err := ctx.WriteStringsFile(first, last); err != nil {
+		panic(err)
+	}
+}
+
 type SaveVarsOpenFile struct {
 	Filename string `op:"string"`
 }
@@ -76,44 +99,49 @@ func (inst SaveVarsOpenFile) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("OPEN(%s)", inst.Filename)
 }
 
+func (inst SaveVarsOpenFile) apply(ctx vm.ExecutionContext) {
+	if err := ctx.OpenVarsFile(inst.Filename, true); err != nil {
+		panic(err)
+	}
+}
+
 type SaveVarsDummy struct{}
 
 func (inst SaveVarsDummy) Display(st *vm.SymbolTable) string {
 	return "DUMMY"
 }
 
+func (inst SaveVarsDummy) apply(ctx vm.ExecutionContext) {}
+
 type SaveVarsCloseFile struct{}
 
 func (inst SaveVarsCloseFile) Display(st *vm.SymbolTable) string {
 	return "CLOSE"
 }
 
+func (inst SaveVarsCloseFile) apply(ctx vm.ExecutionContext) {
+	if err := ctx.CloseVarsFile(); err != nil {
+		panic(err)
+	}
+}
+
 type SaveVars struct {
-	WriteVars    *SaveVarsWriteVars    // 0x01
-	WriteStrings *SaveVarsWriteStrings // 0x02
-	OpenFile     *SaveVarsOpenFile     // 0x03
-	Dummy        *SaveVarsDummy        // 0x04
-	CloseFile    *SaveVarsCloseFile    // 0x1F
+	// Ops are the sub-operations in the order they appear in the script.
+	Ops []SaveVarsOp
 }
 
 func (inst SaveVars) Acronym() string { return "SAVEVARS" }
 
+func (inst SaveVars) Execute(ctx vm.ExecutionContext) {
+	for _, op := range inst.Ops {
+		op.apply(ctx)
+	}
+}
+
 func (inst SaveVars) DisplayOperands(st *vm.SymbolTable) []string {
 	var props []string
-	if inst.WriteVars != nil {
-		props = append(props, inst.WriteVars.Display(st))
-	}
-	if inst.WriteStrings != nil {
-		props = append(props, inst.WriteStrings.Display(st))
-	}
-	if inst.OpenFile != nil {
-		props = append(props, inst.OpenFile.Display(st))
-	}
-	if inst.Dummy != nil {
-		props = append(props, inst.Dummy.Display(st))
-	}
-	if inst.CloseFile != nil {
-		props = append(props, inst.CloseFile.Display(st))
+	for _, op := range inst.Ops {
+		props = append(props, op.Display(st))
 	}
 	return props
 }
@@ -121,34 +149,42 @@ func (inst SaveVars) DisplayOperands(st *vm.SymbolTable) []string {
 func (inst *SaveVars) DecodeOperands(opcode vm.OpCode, r *vm.BytecodeDecoder) error {
 	for {
 		sub := r.DecodeOpCode()
-		switch sub {
-		case 0x00:
+		if sub == 0x00 {
 			return nil
+		}
+		switch sub & 0x1F {
 		case 0x01:
-			inst.WriteVars = &SaveVarsWriteVars{
+			inst.Ops = append(inst.Ops, SaveVarsWriteVars{
 				ResultA: r.DecodeVarRef(),
 				ResultB: r.DecodeVarRef(),
-			}
+			})
 		case 0x02:
-			inst.WriteStrings = &SaveVarsWriteStrings{
+			inst.Ops = append(inst.Ops, SaveVarsWriteStrings{
 				Arg1: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatStringID),
 				Arg2: r.DecodeByteParam(sub, vm.ParamPos2, vm.NumberFormatStringID),
-			}
+			})
 		case 0x03:
-			inst.OpenFile = &SaveVarsOpenFile{
+			inst.Ops = append(inst.Ops, SaveVarsOpenFile{
 				Filename: r.DecodeString(),
-			}
+			})
 		case 0x04:
-			inst.Dummy = new(SaveVarsDummy)
+			inst.Ops = append(inst.Ops, SaveVarsDummy{})
+			return nil
 		case 0x1F:
-			inst.CloseFile = new(SaveVarsCloseFile)
+			inst.Ops = append(inst.Ops, SaveVarsCloseFile{})
+			return nil
 		default:
 			return fmt.Errorf("unknown opcode %02X %02X in save vars op", sub, opcode)
 		}
-		return nil
 	}
 }
 
+// LoadVarsOp is a sub-operation of a load vars instruction, executed in script order.
+type LoadVarsOp interface {
+	Display(st *vm.SymbolTable) string
+	apply(ctx vm.ExecutionContext)
+}
+
 type LoadVarsReadVars struct {
 	ResultA vm.VarRef `op:"result"`
 	ResultB vm.VarRef `op:"result"`
@@ -158,6 +194,19 @@ func (inst LoadVarsReadVars) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("VARS=[%s, %s]", inst.ResultA.Display(st), inst.ResultB.Display(st))
 }
 
+func (inst LoadVarsReadVars) apply(ctx vm.ExecutionContext) {
+	if inst.ResultA.VarID > inst.ResultB.VarID {
+		return
+	}
+	values, err := ctx.ReadVarsFile(int(inst.ResultB.VarID-inst.ResultA.VarID) + 1)
+	if err != nil {
+		panic(err)
+	}
+	for i, v := range values {
+		vm.VarRef{VarID: inst.ResultA.VarID + uint16(i)}.Write(ctx, v)
+	}
+}
+
 type LoadVarsReadStrings struct {
 	Arg1 vm.Param `op:"p8" pos:"1" fmt:"id:string"`
 	Arg2 vm.Param `op:"p8" pos:"2" fmt:"id:string"`
@@ -167,6 +216,13 @@ func (inst LoadVarsReadStrings) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("STRINGS=[%s, %s]", inst.Arg1.Display(st), inst.Arg2.Display(st))
 }
 
+func (inst LoadVarsReadStrings) apply(ctx vm.ExecutionContext) {
+	first, last := vm.StringID(inst.Arg1.Evaluate(ctx)), vm.StringID(inst.Arg2.Evaluate(ctx))
+	if err := ctx.ReadStringsFile(first, last); err != nil {
+		panic(err)
+	}
+}
+
 type LoadVarsOpenFile struct {
 	Filename string `op:"string"`
 }
@@ -175,44 +231,49 @@ func (inst LoadVarsOpenFile) Display(st *vm.SymbolTable) string {
 	return fmt.Sprintf("OPEN(%s)", inst.Filename)
 }
 
+func (inst LoadVarsOpenFile) apply(ctx vm.ExecutionContext) {
+	if err := ctx.OpenVarsFile(inst.Filename, false); err != nil {
+		panic(err)
+	}
+}
+
 type LoadVarsDummy struct{}
 
 func (inst LoadVarsDummy) Display(st *vm.SymbolTable) string {
 	return "DUMMY"
 }
 
+func (inst LoadVarsDummy) apply(ctx vm.ExecutionContext) {}
+
 type LoadVarsCloseFile struct{}
 
 func (inst LoadVarsCloseFile) Display(st *vm.SymbolTable) string {
 	return "CLOSE"
 }
 
+func (inst LoadVarsCloseFile) apply(ctx vm.ExecutionContext) {
+	if err := ctx.CloseVarsFile(); err != nil {
+		panic(err)
+	}
+}
+
 type LoadVars struct {
-	ReadVars    *LoadVarsReadVars    // 0x01
-	ReadStrings *LoadVarsReadStrings // 0x02
-	OpenFile    *LoadVarsOpenFile    // 0x03
-	Dummy       *LoadVarsDummy       // 0x04
-	CloseFile   *LoadVarsCloseFile   // 0x1F
+	// Ops are the sub-operations in the order they appear in the script.
+	Ops []LoadVarsOp
 }
 
 func (inst LoadVars) Acronym() string { return "LOADVARS" }
 
+func (inst LoadVars) Execute(ctx vm.ExecutionContext) {
+	for _, op := range inst.Ops {
+		op.apply(ctx)
+	}
+}
+
 func (inst LoadVars) DisplayOperands(st *vm.SymbolTable) []string {
 	var props []string
-	if inst.ReadVars != nil {
-		props = append(props, inst.ReadVars.Display(st))
-	}
-	if inst.ReadStrings != nil {
-		props = append(props, inst.ReadStrings.Display(st))
-	}
-	if inst.OpenFile != nil {
-		props = append(props, inst.OpenFile.Display(st))
-	}
-	if inst.Dummy != nil {
-		props = append(props, inst.Dummy.Display(st))
-	}
-	if inst.CloseFile != nil {
-		props = append(props, inst.CloseFile.Display(st))
+	for _, op := range inst.Ops {
+		props = append(props, op.Display(st))
 	}
 	return props
 }
@@ -220,31 +281,33 @@ func (inst LoadVars) DisplayOperands(st *vm.SymbolTable) []string {
 func (inst *LoadVars) DecodeOperands(opcode vm.OpCode, r *vm.BytecodeDecoder) error {
 	for {
 		sub := r.DecodeOpCode()
-		switch sub {
-		case 0x00:
+		if sub == 0x00 {
 			return nil
+		}
+		switch sub & 0x1F {
 		case 0x01:
-			inst.ReadVars = &LoadVarsReadVars{
+			inst.Ops = append(inst.Ops, LoadVarsReadVars{
 				ResultA: r.DecodeVarRef(),
 				ResultB: r.DecodeVarRef(),
-			}
+			})
 		case 0x02:
-			inst.ReadStrings = &LoadVarsReadStrings{
+			inst.Ops = append(inst.Ops, LoadVarsReadStrings{
 				Arg1: r.DecodeByteParam(sub, vm.ParamPos1, vm.NumberFormatStringID),
 				Arg2: r.DecodeByteParam(sub, vm.ParamPos2, vm.NumberFormatStringID),
-			}
+			})
 		case 0x03:
-			inst.OpenFile = &LoadVarsOpenFile{
+			inst.Ops = append(inst.Ops, LoadVarsOpenFile{
 				Filename: r.DecodeString(),
-			}
+			})
 		case 0x04:
-			inst.Dummy = new(LoadVarsDummy)
+			inst.Ops = append(inst.Ops, LoadVarsDummy{})
+			return nil
 		case 0x1F:
-			inst.CloseFile = new(LoadVarsCloseFile)
+			inst.Ops = append(inst.Ops, LoadVarsCloseFile{})
+			return nil
 		default:
 			return fmt.Errorf("unknown opcode %02X %02X in load vars op", sub, opcode)
 		}
-		return nil
 	}
 }
 
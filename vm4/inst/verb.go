@@ -135,6 +135,73 @@ type Verb struct {
 
 func (inst Verb) Acronym() string { return "VERB" }
 
+func (inst Verb) Execute(ctx vm.ExecutionContext) {
+	id := vm.VerbID(inst.Verb.Evaluate(ctx))
+	v := ctx.Verb(id)
+	if inst.New != nil {
+		if v = ctx.NewVerb(id); v == nil {
+			panic(fmt.Errorf("cannot create verb %d: too many verbs", id))
+		}
+	}
+	if v == nil {
+		return
+	}
+	if inst.Image != nil {
+		v.ImageObject = vm.ObjectID(inst.Image.Object.Evaluate(ctx))
+		v.ImageRoom = vm.RoomID(ctx.ReadWord(vm.VarRoomResource))
+		v.Name = ""
+	}
+	if inst.Name != nil {
+		v.Name = inst.Name.Name
+		v.ImageObject = 0
+	}
+	if inst.Color != nil {
+		v.Color = inst.Color.Color.Evaluate(ctx)
+	}
+	if inst.HiColor != nil {
+		v.HiColor = inst.HiColor.Color.Evaluate(ctx)
+	}
+	if inst.At != nil {
+		v.X = int(int16(inst.At.Left.Evaluate(ctx)))
+		v.Y = int(int16(inst.At.Top.Evaluate(ctx)))
+	}
+	if inst.On != nil {
+		v.Mode = vm.VerbModeOn
+	}
+	if inst.Off != nil {
+		v.Mode = vm.VerbModeOff
+	}
+	if inst.DimColor != nil {
+		v.DimColor = inst.DimColor.Color.Evaluate(ctx)
+	}
+	if inst.Dim != nil {
+		v.Mode = vm.VerbModeDim
+	}
+	if inst.Key != nil {
+		v.Key = inst.Key.Key.Evaluate(ctx)
+	}
+	if inst.Center != nil {
+		v.Center = true
+	}
+	if inst.NameStr != nil {
+		v.Name = ctx.ReadString(vm.StringID(inst.NameStr.String.Evaluate(ctx)))
+		v.ImageObject = 0
+	}
+	if inst.AssignObject != nil {
+		v.ImageObject = vm.ObjectID(inst.AssignObject.Object.Evaluate(ctx))
+		v.ImageRoom = vm.RoomID(inst.AssignObject.Room.Evaluate(ctx))
+		v.Name = ""
+	}
+	if inst.SetBackColor != nil {
+		v.BackColor = inst.SetBackColor.Color.Evaluate(ctx)
+	}
+	if inst.Delete != nil {
+		ctx.DeleteVerb(id)
+		return
+	}
+	ctx.LayoutVerb(v)
+}
+
 func (inst Verb) DisplayOperands(st *vm.SymbolTable) []string {
 	var props []string
 	if inst.Image != nil {
@@ -262,6 +329,12 @@ type SaveVerbs struct {
 
 func (inst SaveVerbs) Acronym() string { return "SAVEVERB" }
 
+func (inst SaveVerbs) Execute(ctx vm.ExecutionContext) {
+	start := vm.VerbID(inst.Start.Evaluate(ctx))
+	end := vm.VerbID(inst.End.Evaluate(ctx))
+	ctx.SaveVerbs(start, end, inst.Mode.Evaluate(ctx))
+}
+
 type RestoreVerbs struct {
 	Start vm.Param `op:"p8" pos:"1" fmt:"id:verb"`
 	End   vm.Param `op:"p8" pos:"2" fmt:"id:verb"`
@@ -270,6 +343,12 @@ type RestoreVerbs struct {
 
 func (inst RestoreVerbs) Acronym() string { return "RESTVERB" }
 
+func (inst RestoreVerbs) Execute(ctx vm.ExecutionContext) {
+	start := vm.VerbID(inst.Start.Evaluate(ctx))
+	end := vm.VerbID(inst.End.Evaluate(ctx))
+	ctx.RestoreVerbs(start, end, inst.Mode.Evaluate(ctx))
+}
+
 type DeleteVerbs struct {
 	Start vm.Param `op:"p8" pos:"1" fmt:"id:verb"`
 	End   vm.Param `op:"p8" pos:"2" fmt:"id:verb"`
@@ -278,6 +357,12 @@ type DeleteVerbs struct {
 
 func (inst DeleteVerbs) Acronym() string { return "DELVERB" }
 
+func (inst DeleteVerbs) Execute(ctx vm.ExecutionContext) {
+	start := vm.VerbID(inst.Start.Evaluate(ctx))
+	end := vm.VerbID(inst.End.Evaluate(ctx))
+	ctx.DeleteVerbs(start, end, inst.Mode.Evaluate(ctx))
+}
+
 func decodeSaveRestoreDeleteVerbs(
 	opcode vm.OpCode,
 	r *vm.BytecodeDecoder,
@@ -316,3 +401,13 @@ type GetVerbEntrypoint struct {
 }
 
 func (inst GetVerbEntrypoint) Acronym() string { return "GVEN" }
+
+func (inst GetVerbEntrypoint) Execute(ctx vm.ExecutionContext) {
+	obj := vm.ObjectID(inst.Object.Evaluate(ctx))
+	verb := vm.VerbID(inst.Verb.Evaluate(ctx))
+	entry, err := ctx.VerbEntrypoint(obj, verb)
+	if err != nil {
+		panic(err)
+	}
+	inst.Result.Write(ctx, entry)
+}
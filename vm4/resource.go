@@ -3,9 +3,11 @@ package vm4
 import (
 	"encoding/binary"
 	"fmt"
+	"image/color"
 	"io"
 	"os"
 	"path"
+	"slices"
 
 	"github.com/apoloval/scumm-go/ioutils"
 	"github.com/apoloval/scumm-go/vm"
@@ -39,6 +41,10 @@ var (
 	ChunkTypeLC = ChunkType{'L', 'C'}
 	ChunkTypeLS = ChunkType{'L', 'S'}
 	ChunkTypeSC = ChunkType{'S', 'C'} // SC: Global vm.Script
+	ChunkTypeCO = ChunkType{'C', 'O'}
+	ChunkTypeSO = ChunkType{'S', 'O'}
+	ChunkTypeAD = ChunkType{'A', 'D'}
+	ChunkTypeWA = ChunkType{'W', 'A'}
 )
 
 // String implements the Stringer interface.
@@ -101,8 +107,9 @@ func NewResourceBundle(r io.ReadSeeker) *ResourceBundle {
 	}
 }
 
-// GetRoom returns the room r from the resource bundle.
-func (b *ResourceBundle) GetRoom(r vm.IndexedRoom) (*vm.Room, error) {
+// GetRoom returns the room r from the resource bundle. If decode is true, the room scripts are
+// decoded.
+func (b *ResourceBundle) GetRoom(r vm.IndexedRoom, decode bool) (*vm.Room, error) {
 	rem, err := b.seekLF(r.ID)
 	if err != nil {
 		return nil, err
@@ -111,6 +118,11 @@ func (b *ResourceBundle) GetRoom(r vm.IndexedRoom) (*vm.Room, error) {
 	if err := b.decodeRO(room, &rem); err != nil {
 		return nil, err
 	}
+	if decode {
+		if err := room.Decode(inst.Decode); err != nil {
+			return nil, err
+		}
+	}
 	return room, nil
 }
 
@@ -132,6 +144,48 @@ func (b *ResourceBundle) GetScript(r vm.IndexedScript) (*vm.Script, error) {
 	return &vm.Script{ID: r.ID, Bytecode: bytecode}, nil
 }
 
+// GetCostume returns the costume r from the resource bundle.
+func (b *ResourceBundle) GetCostume(r vm.IndexedCostume) (*vm.Costume, error) {
+	_, err := b.seekLF(r.Room)
+	if err != nil {
+		return nil, err
+	}
+
+	rem, err := b.seekChunk(ChunkTypeCO, r.Offset, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, ChunkHeaderSize+rem)
+	binary.LittleEndian.PutUint32(data, ChunkHeaderSize+rem)
+	copy(data[4:], ChunkTypeCO[:])
+	body := data[ChunkHeaderSize:]
+	if err := b.decode(binary.LittleEndian, &body, nil); err != nil {
+		return nil, err
+	}
+	return DecodeCostume(r.ID, data)
+}
+
+// GetSound returns the sound r from the resource bundle.
+func (b *ResourceBundle) GetSound(r vm.IndexedSound) (*vm.Sound, error) {
+	_, err := b.seekLF(r.Room)
+	if err != nil {
+		return nil, err
+	}
+
+	rem, err := b.seekChunk(ChunkTypeSO, r.Offset, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, ChunkHeaderSize+rem)
+	binary.LittleEndian.PutUint32(data, ChunkHeaderSize+rem)
+	copy(data[4:], ChunkTypeSO[:])
+	body := data[ChunkHeaderSize:]
+	if err := b.decode(binary.LittleEndian, &body, nil); err != nil {
+		return nil, err
+	}
+	return DecodeSound(r.ID, data)
+}
+
 func (b *ResourceBundle) decodeRO(r *vm.Room, lfrem *uint32) error {
 	var roh ChunkHeader
 	if err := roh.DecodeAs(b.r, ChunkTypeRO, lfrem); err != nil {
@@ -153,20 +207,21 @@ func (b *ResourceBundle) decodeRO(r *vm.Room, lfrem *uint32) error {
 	if err := b.decodeAndSkipBlock(ChunkTypeSP, &rorem); err != nil {
 		return err
 	}
-	if err := b.decodeAndSkipBlock(ChunkTypeBX, &rorem); err != nil {
+	if err := b.decodeBX(r, &rorem); err != nil {
 		return err
 	}
-	if err := b.decodeAndSkipBlock(ChunkTypePA, &rorem); err != nil {
+	if err := b.decodePA(r, &rorem); err != nil {
 		return err
 	}
 	if err := b.decodeAndSkipBlock(ChunkTypeSA, &rorem); err != nil {
 		return err
 	}
-	if err := b.decodeAndSkipBlock(ChunkTypeBM, &rorem); err != nil {
+	if err := b.decodeBM(r, &rorem); err != nil {
 		return err
 	}
+	images := make(map[vm.ObjectID][]byte, r.NumberOfObjects)
 	for i := 0; i < int(r.NumberOfObjects); i++ {
-		if err := b.decodeAndSkipBlock(ChunkTypeOI, &rorem); err != nil {
+		if err := b.decodeOI(images, &rorem); err != nil {
 			return err
 		}
 	}
@@ -177,16 +232,30 @@ func (b *ResourceBundle) decodeRO(r *vm.Room, lfrem *uint32) error {
 		return err
 	}
 	for i := 0; i < int(r.NumberOfObjects); i++ {
-		if err := b.decodeAndSkipBlock(ChunkTypeOC, &rorem); err != nil {
+		if err := b.decodeOC(r, &rorem); err != nil {
 			return err
 		}
 	}
-	if err := b.decodeAndSkipBlock(ChunkTypeEX, &rorem); err != nil {
+	for i := range r.Objects {
+		obj := &r.Objects[i]
+		data, ok := images[obj.ID]
+		if !ok || obj.Width == 0 || obj.Height == 0 {
+			continue
+		}
+		img, _, err := DecodeBitmap(data, obj.Width, obj.Height)
+		if err != nil {
+			return fmt.Errorf("failed to decode image of object %d in room %d: %w", obj.ID, r.ID, err)
+		}
+		obj.Image = img
+	}
+	if err := b.decodeScriptBlock(ChunkTypeEX, &r.ExitScript, &rorem); err != nil {
 		return err
 	}
-	if err := b.decodeAndSkipBlock(ChunkTypeEN, &rorem); err != nil {
+	r.ExitScript.ID = vm.ScriptIDRoomExit
+	if err := b.decodeScriptBlock(ChunkTypeEN, &r.EntryScript, &rorem); err != nil {
 		return err
 	}
+	r.EntryScript.ID = vm.ScriptIDRoomEntry
 	if err := b.decodeLC(r, &rorem); err != nil {
 		return err
 	}
@@ -239,6 +308,183 @@ func (b *ResourceBundle) decodeHD(r *vm.Room, rorem *uint32) error {
 	return nil
 }
 
+func (b *ResourceBundle) decodeBX(r *vm.Room, rem *uint32) error {
+	var bxh ChunkHeader
+	if err := bxh.DecodeAs(b.r, ChunkTypeBX, rem); err != nil {
+		return err
+	}
+	bxrem := bxh.BodyLen()
+	if *rem < bxrem {
+		return fmt.Errorf("invalid input: BX chunk size exceeds remaining bytes")
+	}
+
+	var n uint8
+	if err := b.decode(binary.LittleEndian, &n, &bxrem); err != nil {
+		return err
+	}
+	for i := 0; i < int(n); i++ {
+		var bx struct {
+			ULX, ULY int16
+			URX, URY int16
+			LRX, LRY int16
+			LLX, LLY int16
+			Mask     uint8
+			Flags    uint8
+			Scale    uint16
+		}
+		if err := b.decode(binary.LittleEndian, &bx, &bxrem); err != nil {
+			return err
+		}
+		r.Boxes = append(r.Boxes, vm.Box{
+			UL:    vm.Point{X: int(bx.ULX), Y: int(bx.ULY)},
+			UR:    vm.Point{X: int(bx.URX), Y: int(bx.URY)},
+			LR:    vm.Point{X: int(bx.LRX), Y: int(bx.LRY)},
+			LL:    vm.Point{X: int(bx.LLX), Y: int(bx.LLY)},
+			Mask:  bx.Mask,
+			Flags: vm.BoxFlags(bx.Flags),
+			Scale: int(bx.Scale),
+		})
+	}
+
+	// The rest of the chunk is the box matrix precomputed by the game tools. It is skipped, since
+	// the engine computes it from the boxes.
+	*rem -= bxh.BodyLen() - bxrem
+	return b.skip(bxrem, rem)
+}
+
+func (b *ResourceBundle) decodePA(r *vm.Room, rem *uint32) error {
+	var pah ChunkHeader
+	if err := pah.DecodeAs(b.r, ChunkTypePA, rem); err != nil {
+		return err
+	}
+	body := make([]byte, pah.BodyLen())
+	if err := b.decode(binary.LittleEndian, &body, rem); err != nil {
+		return err
+	}
+	if len(body) < 2 {
+		return fmt.Errorf("invalid input: PA chunk too short (%d bytes)", len(body))
+	}
+	size := int(binary.LittleEndian.Uint16(body))
+	if 2+size > len(body) {
+		return fmt.Errorf("invalid input: palette size %d exceeds PA chunk", size)
+	}
+	for i := 2; i+3 <= 2+size; i += 3 {
+		r.Palette = append(r.Palette, color.RGBA{body[i], body[i+1], body[i+2], 0xFF})
+	}
+	return nil
+}
+
+func (b *ResourceBundle) decodeBM(r *vm.Room, rem *uint32) error {
+	var bmh ChunkHeader
+	if err := bmh.DecodeAs(b.r, ChunkTypeBM, rem); err != nil {
+		return err
+	}
+	body := make([]byte, bmh.BodyLen())
+	if err := b.decode(binary.LittleEndian, &body, rem); err != nil {
+		return err
+	}
+	img, zplanes, err := DecodeBitmap(body, int(r.Width), int(r.Height))
+	if err != nil {
+		return fmt.Errorf("failed to decode background of room %d: %w", r.ID, err)
+	}
+	r.Background, r.ZPlanes = img, zplanes
+	return nil
+}
+
+// decodeOI reads the image of an object. It is decoded once the size of the object is known from
+// its OC chunk.
+func (b *ResourceBundle) decodeOI(images map[vm.ObjectID][]byte, rem *uint32) error {
+	var oih ChunkHeader
+	if err := oih.DecodeAs(b.r, ChunkTypeOI, rem); err != nil {
+		return err
+	}
+	body := make([]byte, oih.BodyLen())
+	if err := b.decode(binary.LittleEndian, &body, rem); err != nil {
+		return err
+	}
+	if len(body) < 2 {
+		return fmt.Errorf("invalid input: OI chunk too short (%d bytes)", len(body))
+	}
+	images[vm.ObjectID(binary.LittleEndian.Uint16(body))] = body[2:]
+	return nil
+}
+
+func (b *ResourceBundle) decodeOC(r *vm.Room, rem *uint32) error {
+	var och ChunkHeader
+	if err := och.DecodeAs(b.r, ChunkTypeOC, rem); err != nil {
+		return err
+	}
+	body := make([]byte, och.BodyLen())
+	if err := b.decode(binary.LittleEndian, &body, rem); err != nil {
+		return err
+	}
+
+	// The offsets stored in the object code are relative to the beginning of the chunk, header
+	// included.
+	const hlen = 6
+	if len(body) < 13 {
+		return fmt.Errorf("invalid input: OC chunk too short (%d bytes)", len(body))
+	}
+	obj := vm.RoomObject{
+		ID:       vm.ObjectID(binary.LittleEndian.Uint16(body[0:])),
+		X:        int(body[3]) * 8,
+		Y:        int(body[4]&0x7F) * 8,
+		Width:    int(body[5]) * 8,
+		Parent:   body[6],
+		WalkX:    int(int16(binary.LittleEndian.Uint16(body[7:]))),
+		WalkY:    int(int16(binary.LittleEndian.Uint16(body[9:]))),
+		Height:   int(body[11] & 0xF8),
+		ActorDir: body[11] & 0x07,
+	}
+	if body[4]&0x80 != 0 {
+		obj.ParentState = 1
+	}
+
+	code := len(body)
+	for i := 13; ; i += 3 {
+		if i >= len(body) {
+			return fmt.Errorf("invalid input: unterminated verb table in object %d", obj.ID)
+		}
+		if body[i] == 0 {
+			break
+		}
+		if i+3 > len(body) {
+			return fmt.Errorf("invalid input: truncated verb table in object %d", obj.ID)
+		}
+		offset := int(binary.LittleEndian.Uint16(body[i+1:])) - hlen
+		if offset < 0 || offset > len(body) {
+			return fmt.Errorf("invalid input: verb offset out of bounds in object %d", obj.ID)
+		}
+		obj.Verbs = append(obj.Verbs, vm.ObjectVerb{Verb: body[i], Address: uint16(offset)})
+		code = min(code, offset)
+	}
+	for i := range obj.Verbs {
+		obj.Verbs[i].Address -= uint16(code)
+	}
+
+	if name := int(body[12]) - hlen; name >= 0 && name < len(body) {
+		end := name
+		for end < len(body) && body[end] != 0 {
+			end++
+		}
+		obj.Name = string(body[name:end])
+	}
+
+	obj.Script = vm.Script{ID: vm.ScriptID(obj.ID), Bytecode: body[code:]}
+	obj.CodeOffset = uint16(code + hlen)
+	r.Objects = append(r.Objects, obj)
+	return nil
+}
+
+func (b *ResourceBundle) decodeScriptBlock(t ChunkType, s *vm.Script, rem *uint32) error {
+	var h ChunkHeader
+	if err := h.DecodeAs(b.r, t, rem); err != nil {
+		return err
+	}
+	s.Bytecode = make([]byte, h.BodyLen())
+	return b.decode(binary.LittleEndian, &s.Bytecode, rem)
+}
+
 func (b *ResourceBundle) decodeLC(r *vm.Room, rem *uint32) error {
 	var lch ChunkHeader
 	if err := lch.DecodeAs(b.r, ChunkTypeLC, rem); err != nil {
@@ -383,7 +629,7 @@ func NewResourceManager(basePath string, index vm.Index) *ResourceManager {
 }
 
 // GetRoom implements the ResourceManager interface.
-func (m *ResourceManager) GetRoom(id vm.RoomID) (*vm.Room, error) {
+func (m *ResourceManager) GetRoom(id vm.RoomID, decode bool) (*vm.Room, error) {
 	r, ok := m.index.Rooms[id]
 	if !ok {
 		return nil, fmt.Errorf("unknown room ID %d", id)
@@ -392,19 +638,100 @@ func (m *ResourceManager) GetRoom(id vm.RoomID) (*vm.Room, error) {
 	if err != nil {
 		return nil, err
 	}
-	return bundle.GetRoom(r)
+	return bundle.GetRoom(r, decode)
 }
 
 // GetRoomByName implements the ResourceManager interface.
-func (b *ResourceManager) GetRoomByName(name vm.RoomName) (*vm.Room, error) {
+func (b *ResourceManager) GetRoomByName(name vm.RoomName, decode bool) (*vm.Room, error) {
 	for _, r := range b.index.Rooms {
 		if r.Name == name {
-			return b.GetRoom(r.ID)
+			return b.GetRoom(r.ID, decode)
 		}
 	}
 	return nil, fmt.Errorf("unknown room %s", name)
 }
 
+// GetObjects implements the ResourceManager interface.
+func (m *ResourceManager) GetObjects() map[vm.ObjectID]vm.IndexedObject {
+	return m.index.Objects
+}
+
+// GetObjectRoom implements the ResourceManager interface. The index does not tell where the code
+// of the objects is, so the rooms are searched in order of their IDs.
+func (m *ResourceManager) GetObjectRoom(id vm.ObjectID) (vm.RoomID, error) {
+	rooms := make([]vm.RoomID, 0, len(m.index.Rooms))
+	for rid := range m.index.Rooms {
+		rooms = append(rooms, rid)
+	}
+	slices.Sort(rooms)
+	for _, rid := range rooms {
+		room, err := m.GetRoom(rid, false)
+		if err != nil {
+			return 0, err
+		}
+		if room.Object(id) != nil {
+			return rid, nil
+		}
+	}
+	return 0, fmt.Errorf("object %d not found in any room", id)
+}
+
+// GetCharset implements the ResourceManager interface. The charsets are stored in their own files,
+// numbered from 900.
+func (m *ResourceManager) GetCharset(id vm.CharsetID) (*vm.Charset, error) {
+	paths := []string{
+		path.Join(m.basePath, fmt.Sprintf("%03d.LFL", 900+id)),
+		path.Join(m.basePath, fmt.Sprintf("%03d.lfl", 900+id)),
+	}
+	for _, p := range paths {
+		file, err := os.Open(p)
+		if err != nil {
+			continue
+		}
+		defer file.Close()
+		charset, err := DecodeCharset(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode charset %d: %w", id, err)
+		}
+		return &charset, nil
+	}
+	return nil, fmt.Errorf("failed to open charset %d file", id)
+}
+
+// GetCostume implements the ResourceManager interface.
+func (m *ResourceManager) GetCostume(id vm.CostumeID) (*vm.Costume, error) {
+	c, ok := m.index.Costumes[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown costume ID %d", id)
+	}
+	r, ok := m.index.Rooms[c.Room]
+	if !ok {
+		return nil, fmt.Errorf("unknown room ID %d", c.Room)
+	}
+	bundle, err := m.getBundle(int(r.FileNumber))
+	if err != nil {
+		return nil, err
+	}
+	return bundle.GetCostume(c)
+}
+
+// GetSound implements the ResourceManager interface.
+func (m *ResourceManager) GetSound(id vm.SoundID) (*vm.Sound, error) {
+	s, ok := m.index.Sounds[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown sound ID %d", id)
+	}
+	r, ok := m.index.Rooms[s.Room]
+	if !ok {
+		return nil, fmt.Errorf("unknown room ID %d", s.Room)
+	}
+	bundle, err := m.getBundle(int(r.FileNumber))
+	if err != nil {
+		return nil, err
+	}
+	return bundle.GetSound(s)
+}
+
 // GetScript implements the ResourceManager interface.
 func (m *ResourceManager) GetScript(id vm.ScriptID, decode bool) (*vm.Script, error) {
 	s, ok := m.index.Scripts[id]
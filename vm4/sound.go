@@ -0,0 +1,86 @@
+package vm4
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/apoloval/scumm-go/vm"
+)
+
+// DecodeSound decodes a sound from the data of its SO chunk, including the chunk header. The SO
+// chunk contains AD, RO and WA chunks with the data of the sound for the AdLib, the Roland and the
+// PC speaker devices, any of them possibly missing, either directly or inside nested SO chunks.
+func DecodeSound(id vm.SoundID, data []byte) (*vm.Sound, error) {
+	sound := &vm.Sound{ID: id}
+	if err := decodeSoundChunk(sound, data); err != nil {
+		return nil, fmt.Errorf("invalid sound %d: %w", id, err)
+	}
+	return sound, nil
+}
+
+func decodeSoundChunk(sound *vm.Sound, data []byte) error {
+	h, err := soundChunkHeader(data, 0)
+	if err != nil {
+		return err
+	}
+	if h.Type != ChunkTypeSO {
+		return fmt.Errorf("unexpected chunk type %s", h.Type)
+	}
+	data = data[:h.Size]
+	for at := ChunkHeaderSize; at < len(data); {
+		child, err := soundChunkHeader(data, at)
+		if err != nil {
+			return err
+		}
+		chunk := data[at : at+int(child.Size)]
+		switch child.Type {
+		case ChunkTypeSO:
+			if err := decodeSoundChunk(sound, chunk); err != nil {
+				return err
+			}
+		case ChunkTypeAD:
+			sound.AdLib = chunk[ChunkHeaderSize:]
+		case ChunkTypeRO:
+			sound.Roland = chunk[ChunkHeaderSize:]
+		case ChunkTypeWA:
+			sound.Speaker = chunk[ChunkHeaderSize:]
+		}
+		at += int(child.Size)
+	}
+	return nil
+}
+
+// soundChunkHeader returns the header of the chunk at the given offset, checking that the chunk
+// fits in the data.
+func soundChunkHeader(data []byte, at int) (ChunkHeader, error) {
+	if len(data)-at < ChunkHeaderSize {
+		return ChunkHeader{}, fmt.Errorf("truncated chunk header at offset %d", at)
+	}
+	h := ChunkHeader{Size: binary.LittleEndian.Uint32(data[at:])}
+	copy(h.Type[:], data[at+4:])
+	if h.Size < ChunkHeaderSize || uint32(len(data)-at) < h.Size {
+		return ChunkHeader{}, fmt.Errorf("invalid size %d of %s chunk at offset %d", h.Size, h.Type, at)
+	}
+	return h, nil
+}
+
+// DecodeSpeakerTones decodes the tones played by the PC speaker from the data of a WA chunk. The
+// format is not confirmed yet: the data is read as a sequence of tones of four bytes, the divisor
+// of the PIT frequency and the duration in jiffies, both little-endian words. A zero divisor is a
+// rest, and a 0xFFFF divisor ends the sequence.
+func DecodeSpeakerTones(data []byte) ([]vm.SpeakerTone, error) {
+	var tones []vm.SpeakerTone
+	for at := 0; at < len(data); at += 4 {
+		if at+2 <= len(data) && binary.LittleEndian.Uint16(data[at:]) == 0xFFFF {
+			break
+		}
+		if at+4 > len(data) {
+			return nil, fmt.Errorf("invalid speaker data: truncated tone at offset %d", at)
+		}
+		tones = append(tones, vm.SpeakerTone{
+			Divisor: binary.LittleEndian.Uint16(data[at:]),
+			Jiffies: int(binary.LittleEndian.Uint16(data[at+2:])),
+		})
+	}
+	return tones, nil
+}
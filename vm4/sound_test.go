@@ -0,0 +1,42 @@
+package vm4_test
+
+import (
+	"testing"
+
+	"github.com/apoloval/scumm-go/vm"
+	"github.com/apoloval/scumm-go/vm4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeSound(t *testing.T) {
+	data := []byte{
+		0x2C, 0x00, 0x00, 0x00, 'S', 'O', // 0000: chunk header
+		0x08, 0x00, 0x00, 0x00, 'A', 'D', // 0006: AdLib data
+		0x01, 0x02,
+		0x07, 0x00, 0x00, 0x00, 'X', 'X', // 000E: unknown chunk
+		0x03,
+		0x17, 0x00, 0x00, 0x00, 'S', 'O', // 0015: nested chunk
+		0x11, 0x00, 0x00, 0x00, 'W', 'A', // 001B: PC speaker data
+		0xA9, 0x04, 0x02, 0x00, // tone of 1000 Hz, 2 jiffies
+		0x00, 0x00, 0x01, 0x00, // rest, 1 jiffy
+		0xFF, 0xFF, 0x00, // end
+	}
+	sound, err := vm4.DecodeSound(3, data)
+	require.NoError(t, err)
+	assert.Equal(t, vm.SoundID(3), sound.ID)
+	assert.Equal(t, []byte{0x01, 0x02}, sound.AdLib)
+	assert.Nil(t, sound.Roland)
+	assert.Equal(t, data[0x21:0x2C], sound.Speaker)
+
+	tones, err := vm4.DecodeSpeakerTones(sound.Speaker)
+	require.NoError(t, err)
+	assert.Equal(t, []vm.SpeakerTone{{Divisor: 1193, Jiffies: 2}, {Divisor: 0, Jiffies: 1}}, tones)
+
+	_, err = vm4.DecodeSpeakerTones([]byte{0xA9, 0x04, 0x02})
+	assert.ErrorContains(t, err, "truncated tone at offset 0")
+
+	data[0x15] = 0x40
+	_, err = vm4.DecodeSound(3, data)
+	assert.ErrorContains(t, err, "invalid sound 3: invalid size 64 of SO chunk at offset 21")
+}
@@ -6,82 +6,66 @@ import "github.com/apoloval/scumm-go/vm"
 func DefaultSymbolTable() *vm.SymbolTable {
 	st := vm.NewSymbolTable()
 	st.Declare(vm.SymbolTypeLabel, "START", 0)
-	for i, name := range defaultVarNames {
-		if name != "" {
-			st.Declare(vm.SymbolTypeVar, name, uint16(i))
-		}
+	for idx, name := range defaultVarNames {
+		st.Declare(vm.SymbolTypeVar, name, idx)
 	}
 	return st
 }
 
-var defaultVarNames = []string{
-	/* 0 */
-	"VAR_RESULT",
-	"VAR_EGO",
-	"VAR_CAMERA_POS_X",
-	"VAR_HAVE_MSG",
-	/* 4 */
-	"VAR_ROOM",
-	"VAR_OVERRIDE",
-	"VAR_MACHINE_SPEED",
-	"VAR_ME",
-	/* 8 */
-	"VAR_NUM_ACTOR",
-	"VAR_CURRENT_LIGHTS",
-	"VAR_CURRENTDRIVE",
-	"VAR_TMR_1",
-	/* 12 */
-	"VAR_TMR_2",
-	"VAR_TMR_3",
-	"VAR_MUSIC_TIMER",
-	"VAR_ACTOR_RANGE_MIN",
-	/* 16 */
-	"VAR_ACTOR_RANGE_MAX",
-	"VAR_CAMERA_MIN_X",
-	"VAR_CAMERA_MAX_X",
-	"VAR_TIMER_NEXT",
-	/* 20 */
-	"VAR_VIRT_MOUSE_X",
-	"VAR_VIRT_MOUSE_Y",
-	"VAR_ROOM_RESOURCE",
-	"VAR_LAST_SOUND",
-	/* 24 */
-	"VAR_CUTSCENEEXIT_KEY",
-	"VAR_TALK_ACTOR",
-	"VAR_CAMERA_FAST_X",
-	"VAR_SCROLL_SCRIPT",
-	/* 28 */
-	"VAR_ENTRY_SCRIPT",
-	"VAR_ENTRY_SCRIPT2",
-	"VAR_EXIT_SCRIPT",
-	"VAR_EXIT_SCRIPT2",
-	/* 32 */
-	"VAR_VERB_SCRIPT",
-	"VAR_SENTENCE_SCRIPT",
-	"VAR_INVENTORY_SCRIPT",
-	"VAR_CUTSCENE_START_SCRIPT",
-	/* 36 */
-	"VAR_CUTSCENE_END_SCRIPT",
-	"VAR_CHARINC",
-	"VAR_WALKTO_OBJ",
-	"VAR_DEBUGMODE",
-	/* 40 */
-	"VAR_HEAPSPACE",
-	"",
-	"VAR_RESTART_KEY",
-	"VAR_PAUSE_KEY",
-	/* 44 */
-	"VAR_MOUSE_X",
-	"VAR_MOUSE_Y",
-	"VAR_TIMER",
-	"VAR_TIMER_TOTAL",
-	/* 48 */
-	"VAR_SOUNDCARD",
-	"VAR_VIDEOMODE",
-	"VAR_MAINMENU_KEY",
-	"VAR_FIXEDDISK",
-	/* 52 */
-	"VAR_CURSORSTATE",
-	"VAR_USERPUT",
-	"VAR_V5_TALK_STRING_Y",
+// defaultVarNames are the names of the well-known word variables of the engine.
+var defaultVarNames = map[uint16]string{
+	vm.VarResult:              "VAR_RESULT",
+	vm.VarEgo:                 "VAR_EGO",
+	vm.VarCameraPosX:          "VAR_CAMERA_POS_X",
+	vm.VarHaveMsg:             "VAR_HAVE_MSG",
+	vm.VarRoom:                "VAR_ROOM",
+	vm.VarOverride:            "VAR_OVERRIDE",
+	vm.VarMachineSpeed:        "VAR_MACHINE_SPEED",
+	vm.VarMe:                  "VAR_ME",
+	vm.VarNumActor:            "VAR_NUM_ACTOR",
+	vm.VarCurrentLights:       "VAR_CURRENT_LIGHTS",
+	vm.VarCurrentDrive:        "VAR_CURRENTDRIVE",
+	vm.VarTmr1:                "VAR_TMR_1",
+	vm.VarTmr2:                "VAR_TMR_2",
+	vm.VarTmr3:                "VAR_TMR_3",
+	vm.VarMusicTimer:          "VAR_MUSIC_TIMER",
+	vm.VarActorRangeMin:       "VAR_ACTOR_RANGE_MIN",
+	vm.VarActorRangeMax:       "VAR_ACTOR_RANGE_MAX",
+	vm.VarCameraMinX:          "VAR_CAMERA_MIN_X",
+	vm.VarCameraMaxX:          "VAR_CAMERA_MAX_X",
+	vm.VarTimerNext:           "VAR_TIMER_NEXT",
+	vm.VarVirtMouseX:          "VAR_VIRT_MOUSE_X",
+	vm.VarVirtMouseY:          "VAR_VIRT_MOUSE_Y",
+	vm.VarRoomResource:        "VAR_ROOM_RESOURCE",
+	vm.VarLastSound:           "VAR_LAST_SOUND",
+	vm.VarCutsceneExitKey:     "VAR_CUTSCENEEXIT_KEY",
+	vm.VarTalkActor:           "VAR_TALK_ACTOR",
+	vm.VarCameraFastX:         "VAR_CAMERA_FAST_X",
+	vm.VarScrollScript:        "VAR_SCROLL_SCRIPT",
+	vm.VarEntryScript:         "VAR_ENTRY_SCRIPT",
+	vm.VarEntryScript2:        "VAR_ENTRY_SCRIPT2",
+	vm.VarExitScript:          "VAR_EXIT_SCRIPT",
+	vm.VarExitScript2:         "VAR_EXIT_SCRIPT2",
+	vm.VarVerbScript:          "VAR_VERB_SCRIPT",
+	vm.VarSentenceScript:      "VAR_SENTENCE_SCRIPT",
+	vm.VarInventoryScript:     "VAR_INVENTORY_SCRIPT",
+	vm.VarCutsceneStartScript: "VAR_CUTSCENE_START_SCRIPT",
+	vm.VarCutsceneEndScript:   "VAR_CUTSCENE_END_SCRIPT",
+	vm.VarCharInc:             "VAR_CHARINC",
+	vm.VarWalkToObj:           "VAR_WALKTO_OBJ",
+	vm.VarDebugMode:           "VAR_DEBUGMODE",
+	vm.VarHeapSpace:           "VAR_HEAPSPACE",
+	vm.VarRestartKey:          "VAR_RESTART_KEY",
+	vm.VarPauseKey:            "VAR_PAUSE_KEY",
+	vm.VarMouseX:              "VAR_MOUSE_X",
+	vm.VarMouseY:              "VAR_MOUSE_Y",
+	vm.VarTimer:               "VAR_TIMER",
+	vm.VarTimerTotal:          "VAR_TIMER_TOTAL",
+	vm.VarSoundcard:           "VAR_SOUNDCARD",
+	vm.VarVideoMode:           "VAR_VIDEOMODE",
+	vm.VarMainMenuKey:         "VAR_MAINMENU_KEY",
+	vm.VarFixedDisk:           "VAR_FIXEDDISK",
+	vm.VarCursorState:         "VAR_CURSORSTATE",
+	vm.VarUserput:             "VAR_USERPUT",
+	vm.VarTalkStringY:         "VAR_V5_TALK_STRING_Y",
 }